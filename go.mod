@@ -3,6 +3,8 @@ module github.com/ankitrgadiya/operatorlib
 go 1.12
 
 require (
+	github.com/evanphx/json-patch v4.5.0+incompatible
+	github.com/go-logr/logr v0.1.0
 	github.com/gogo/protobuf v1.2.1 // indirect
 	github.com/golang/mock v1.3.1
 	github.com/imdario/mergo v0.3.6
@@ -18,4 +20,5 @@ require (
 	k8s.io/client-go v11.0.1-0.20190409021438-1a26190bd76a+incompatible
 	k8s.io/klog v0.4.0 // indirect
 	sigs.k8s.io/controller-runtime v0.2.0
+	sigs.k8s.io/yaml v1.1.0
 )
@@ -0,0 +1,177 @@
+package conditions
+
+import (
+	"fmt"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// ReasonFailed is the reason of the conditions set from a failed
+	// reconcile.
+	ReasonFailed = "ReconcileFailed"
+	// ReasonInProgress is the reason of the conditions set from a
+	// reconcile which asked for requeue or failed with
+	// operation.ConflictError.
+	ReasonInProgress = "ReconcileInProgress"
+	// ReasonSucceeded is the reason of the conditions set from a
+	// complete reconcile.
+	ReasonSucceeded = "ReconcileSucceeded"
+)
+
+// Set sets the condition on the object, replacing the existing
+// condition of the same type. LastTransitionTime of the condition
+// passed is ignored. It is set to the current time if the status
+// changed, or else the one of the existing condition is kept, so
+// setting the same condition again does not change the object. It
+// reports if the conditions of the object are changed.
+func Set(o Object, condition Condition) bool {
+	conditions := o.GetConditions()
+
+	for i, existing := range conditions {
+		if existing.Type != condition.Type {
+			continue
+		}
+
+		if existing.Status == condition.Status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		} else {
+			condition.LastTransitionTime = metav1.Now()
+		}
+		if existing == condition {
+			return false
+		}
+
+		updated := append([]Condition{}, conditions...)
+		updated[i] = condition
+		o.SetConditions(updated)
+		return true
+	}
+
+	condition.LastTransitionTime = metav1.Now()
+	o.SetConditions(append(append([]Condition{}, conditions...), condition))
+	return true
+}
+
+// Get returns a copy of the condition of the type passed, or nil if
+// the object does not have one.
+func Get(o Object, t Type) *Condition {
+	for _, c := range o.GetConditions() {
+		if c.Type == t {
+			return c.DeepCopy()
+		}
+	}
+	return nil
+}
+
+// Remove removes the condition of the type passed from the object.
+// It reports if the conditions of the object are changed.
+func Remove(o Object, t Type) bool {
+	conditions := o.GetConditions()
+
+	updated := make([]Condition, 0, len(conditions))
+	for _, c := range conditions {
+		if c.Type != t {
+			updated = append(updated, c)
+		}
+	}
+	if len(updated) == len(conditions) {
+		return false
+	}
+
+	o.SetConditions(updated)
+	return true
+}
+
+// IsTrue reports if the object has the condition of the type passed
+// with status True.
+func IsTrue(o Object, t Type) bool {
+	return hasStatus(o, t, corev1.ConditionTrue)
+}
+
+// IsFalse reports if the object has the condition of the type passed
+// with status False.
+func IsFalse(o Object, t Type) bool {
+	return hasStatus(o, t, corev1.ConditionFalse)
+}
+
+// IsUnknown reports if the object has the condition of the type
+// passed with status Unknown, or does not have it at all.
+func IsUnknown(o Object, t Type) bool {
+	c := Get(o, t)
+	return c == nil || c.Status == corev1.ConditionUnknown
+}
+
+func hasStatus(o Object, t Type, status corev1.ConditionStatus) bool {
+	c := Get(o, t)
+	return c != nil && c.Status == status
+}
+
+// ReadyFromResult returns the Ready condition for the result and
+// error returned by the operation functions, like
+// operation.CreateOrUpdate. The results of several calls can be
+// merged with operation.MergeResults first. Ready is True only if
+// there is no error and no requeue.
+func ReadyFromResult(r reconcile.Result, err error) Condition {
+	reason, message := fromResult(r, err)
+	return newCondition(Ready, reason == ReasonSucceeded, reason, message)
+}
+
+// ProgressingFromResult returns the Progressing condition for the
+// result and error returned by the operation functions. Progressing
+// is True only if requeue is asked for or the error is
+// operation.ConflictError, which is retried rather than reported as
+// failure.
+func ProgressingFromResult(r reconcile.Result, err error) Condition {
+	reason, message := fromResult(r, err)
+	return newCondition(Progressing, reason == ReasonInProgress, reason, message)
+}
+
+// DegradedFromResult returns the Degraded condition for the result
+// and error returned by the operation functions. Degraded is True
+// only if there is an error other than operation.ConflictError.
+func DegradedFromResult(r reconcile.Result, err error) Condition {
+	reason, message := fromResult(r, err)
+	return newCondition(Degraded, reason == ReasonFailed, reason, message)
+}
+
+// SetFromResult sets the Ready, Progressing and Degraded conditions
+// on the object for the result and error returned by the operation
+// functions. It reports if the conditions of the object are changed.
+func SetFromResult(o Object, r reconcile.Result, err error) bool {
+	ready := Set(o, ReadyFromResult(r, err))
+	progressing := Set(o, ProgressingFromResult(r, err))
+	degraded := Set(o, DegradedFromResult(r, err))
+	return ready || progressing || degraded
+}
+
+// fromResult returns the reason and message for the result and error
+// passed.
+func fromResult(r reconcile.Result, err error) (string, string) {
+	switch {
+	case operation.IsConflictError(err):
+		// The message is kept the same, as the error tells the
+		// resourceVersion which changes every time.
+		return ReasonInProgress, "object is modified in cluster, reconcile is requeued"
+	case err != nil:
+		return ReasonFailed, err.Error()
+	case r.RequeueAfter > 0:
+		return ReasonInProgress, fmt.Sprintf("reconcile is requeued after %s", r.RequeueAfter)
+	case r.Requeue:
+		return ReasonInProgress, "reconcile is requeued"
+	default:
+		return ReasonSucceeded, "reconcile succeeded"
+	}
+}
+
+func newCondition(t Type, status bool, reason, message string) Condition {
+	c := Condition{Type: t, Status: corev1.ConditionFalse, Reason: reason, Message: message}
+	if status {
+		c.Status = corev1.ConditionTrue
+	}
+	return c
+}
@@ -0,0 +1,198 @@
+package conditions_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/conditions"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+type testStatus struct {
+	Conditions []conditions.Condition
+}
+
+func (s *testStatus) GetConditions() []conditions.Condition {
+	return s.Conditions
+}
+
+func (s *testStatus) SetConditions(c []conditions.Condition) {
+	s.Conditions = c
+}
+
+var past = metav1.NewTime(time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC))
+
+func setup() *testStatus {
+	return &testStatus{Conditions: []conditions.Condition{
+		{Type: conditions.Ready, Status: corev1.ConditionFalse, LastTransitionTime: past, Reason: "Test"},
+		{Type: conditions.Degraded, Status: corev1.ConditionUnknown, LastTransitionTime: past},
+	}}
+}
+
+func TestSet(t *testing.T) {
+	t.Run("add condition", func(t *testing.T) {
+		s := setup()
+		changed := conditions.Set(s, conditions.Condition{Type: conditions.Progressing, Status: corev1.ConditionTrue})
+		assert.True(t, changed)
+		assert.Len(t, s.Conditions, 3)
+		assert.True(t, conditions.IsTrue(s, conditions.Progressing))
+		assert.False(t, conditions.Get(s, conditions.Progressing).LastTransitionTime.IsZero())
+	})
+	t.Run("change status", func(t *testing.T) {
+		s := setup()
+		changed := conditions.Set(s, conditions.Condition{Type: conditions.Ready, Status: corev1.ConditionTrue, Reason: "Test"})
+		assert.True(t, changed)
+		assert.Len(t, s.Conditions, 2)
+		assert.True(t, conditions.IsTrue(s, conditions.Ready))
+		assert.True(t, past.Before(&conditions.Get(s, conditions.Ready).LastTransitionTime))
+	})
+	t.Run("change reason", func(t *testing.T) {
+		s := setup()
+		changed := conditions.Set(s, conditions.Condition{Type: conditions.Ready, Status: corev1.ConditionFalse, Reason: "Other", Message: "test"})
+		assert.True(t, changed)
+		c := conditions.Get(s, conditions.Ready)
+		assert.Equal(t, "Other", c.Reason)
+		assert.Equal(t, "test", c.Message)
+		assert.Equal(t, past, c.LastTransitionTime)
+	})
+	t.Run("set same condition", func(t *testing.T) {
+		s := setup()
+		changed := conditions.Set(s, conditions.Condition{Type: conditions.Ready, Status: corev1.ConditionFalse, Reason: "Test", LastTransitionTime: metav1.Now()})
+		assert.False(t, changed)
+		assert.Equal(t, setup(), s)
+	})
+	t.Run("existing slice is not changed", func(t *testing.T) {
+		s := setup()
+		existing := s.Conditions
+		conditions.Set(s, conditions.Condition{Type: conditions.Ready, Status: corev1.ConditionTrue})
+		assert.Equal(t, corev1.ConditionFalse, existing[0].Status)
+	})
+}
+
+func TestGet(t *testing.T) {
+	s := setup()
+
+	c := conditions.Get(s, conditions.Ready)
+	assert.Equal(t, &s.Conditions[0], c)
+	c.Status = corev1.ConditionTrue
+	assert.False(t, conditions.IsTrue(s, conditions.Ready))
+
+	assert.Nil(t, conditions.Get(s, conditions.Progressing))
+}
+
+func TestRemove(t *testing.T) {
+	s := setup()
+
+	assert.True(t, conditions.Remove(s, conditions.Ready))
+	assert.Len(t, s.Conditions, 1)
+	assert.Nil(t, conditions.Get(s, conditions.Ready))
+
+	assert.False(t, conditions.Remove(s, conditions.Ready))
+	assert.Len(t, s.Conditions, 1)
+}
+
+func TestIs(t *testing.T) {
+	s := setup()
+
+	assert.False(t, conditions.IsTrue(s, conditions.Ready))
+	assert.True(t, conditions.IsFalse(s, conditions.Ready))
+	assert.False(t, conditions.IsUnknown(s, conditions.Ready))
+
+	assert.False(t, conditions.IsTrue(s, conditions.Degraded))
+	assert.False(t, conditions.IsFalse(s, conditions.Degraded))
+	assert.True(t, conditions.IsUnknown(s, conditions.Degraded))
+
+	assert.False(t, conditions.IsTrue(s, conditions.Progressing))
+	assert.False(t, conditions.IsFalse(s, conditions.Progressing))
+	assert.True(t, conditions.IsUnknown(s, conditions.Progressing))
+}
+
+func TestFromResult(t *testing.T) {
+	tests := []struct {
+		name        string
+		result      reconcile.Result
+		err         error
+		reason      string
+		message     string
+		ready       corev1.ConditionStatus
+		progressing corev1.ConditionStatus
+		degraded    corev1.ConditionStatus
+	}{
+		{
+			name:        "success",
+			reason:      conditions.ReasonSucceeded,
+			message:     "reconcile succeeded",
+			ready:       corev1.ConditionTrue,
+			progressing: corev1.ConditionFalse,
+			degraded:    corev1.ConditionFalse,
+		},
+		{
+			name:        "requeue",
+			result:      reconcile.Result{Requeue: true},
+			reason:      conditions.ReasonInProgress,
+			message:     "reconcile is requeued",
+			ready:       corev1.ConditionFalse,
+			progressing: corev1.ConditionTrue,
+			degraded:    corev1.ConditionFalse,
+		},
+		{
+			name:        "requeue after",
+			result:      reconcile.Result{RequeueAfter: 5 * time.Second},
+			reason:      conditions.ReasonInProgress,
+			message:     "reconcile is requeued after 5s",
+			ready:       corev1.ConditionFalse,
+			progressing: corev1.ConditionTrue,
+			degraded:    corev1.ConditionFalse,
+		},
+		{
+			name:        "error",
+			result:      reconcile.Result{Requeue: true},
+			err:         errors.New("test error"),
+			reason:      conditions.ReasonFailed,
+			message:     "test error",
+			ready:       corev1.ConditionFalse,
+			progressing: corev1.ConditionFalse,
+			degraded:    corev1.ConditionTrue,
+		},
+		{
+			name:        "conflict",
+			err:         pkgerrors.Wrap(operation.NewConflictError("test", "test", errors.New("test error")), "failed to update"),
+			reason:      conditions.ReasonInProgress,
+			message:     "object is modified in cluster, reconcile is requeued",
+			ready:       corev1.ConditionFalse,
+			progressing: corev1.ConditionTrue,
+			degraded:    corev1.ConditionFalse,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := setup()
+
+			changed := conditions.SetFromResult(s, test.result, test.err)
+			assert.True(t, changed)
+			assert.Len(t, s.Conditions, 3)
+
+			for typ, status := range map[conditions.Type]corev1.ConditionStatus{
+				conditions.Ready:       test.ready,
+				conditions.Progressing: test.progressing,
+				conditions.Degraded:    test.degraded,
+			} {
+				c := conditions.Get(s, typ)
+				assert.Equal(t, status, c.Status, string(typ))
+				assert.Equal(t, test.reason, c.Reason)
+				assert.Equal(t, test.message, c.Message)
+			}
+
+			changed = conditions.SetFromResult(s, test.result, test.err)
+			assert.False(t, changed)
+		})
+	}
+}
@@ -0,0 +1,4 @@
+// Package conditions provides functions for managing the conditions
+// in the status of the owner object, like Ready, Progressing and
+// Degraded.
+package conditions
@@ -0,0 +1,52 @@
+package conditions_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/conditions"
+	"github.com/ankitrgadiya/operatorlib/pkg/configmap"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+	"github.com/ankitrgadiya/operatorlib/pkg/service"
+	"github.com/ankitrgadiya/operatorlib/pkg/status"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleSetFromResult() {
+	r1, err1 := configmap.CreateOrUpdate(configmap.Conf{
+		Instance:  ownerObject,
+		Reconcile: ownerReconcile,
+		Name:      "configmap-test",
+		Namespace: ownerObject.GetNamespace(),
+	})
+	r2, err2 := service.CreateOrUpdate(service.Conf{
+		Instance:  ownerObject,
+		Reconcile: ownerReconcile,
+		Name:      "service-test",
+		Namespace: ownerObject.GetNamespace(),
+	})
+
+	reconcileErr := err1
+	if reconcileErr == nil {
+		reconcileErr = err2
+	}
+
+	err := status.Update(status.Conf{
+		Instance:  ownerObject,
+		Reconcile: ownerReconcile,
+		// The owner object implements the conditions.Object interface
+		// by returning and replacing the conditions in its status.
+		SetStatusFunc: func(o interfaces.Object) error {
+			// The results of the operations are merged so that the
+			// owner object is Ready only when none of them asked for
+			// requeue.
+			conditions.SetFromResult(o.(conditions.Object), operation.MergeResults(r1, r2), reconcileErr)
+			return nil
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,64 @@
+package conditions
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Type is the type of a condition. Only one condition of a type is
+// kept on an object.
+type Type string
+
+const (
+	// Ready means the owner object is reconciled and its children are
+	// ready to be used.
+	Ready Type = "Ready"
+	// Progressing means the owner object is still being reconciled,
+	// like waiting for its children to be created or deleted.
+	Progressing Type = "Progressing"
+	// Degraded means the owner object failed to reconcile.
+	Degraded Type = "Degraded"
+)
+
+// Condition is the state of an aspect of the owner object. It can be
+// used as the element type of the conditions slice in the status of
+// custom resources.
+type Condition struct {
+	// Type of the condition.
+	Type Type `json:"type"`
+	// Status of the condition, one of True, False or Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+	// LastTransitionTime is the last time the condition changed from
+	// one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+	// Reason is the machine-readable reason of the last transition,
+	// in CamelCase.
+	Reason string `json:"reason,omitempty"`
+	// Message is the human-readable message with the details of the
+	// last transition.
+	Message string `json:"message,omitempty"`
+}
+
+// DeepCopyInto copies the receiver into out. in must be non-nil.
+func (in *Condition) DeepCopyInto(out *Condition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy copies the receiver, creating a new Condition.
+func (in *Condition) DeepCopy() *Condition {
+	if in == nil {
+		return nil
+	}
+	out := new(Condition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// Object is the interface implemented by the owner objects, or their
+// status, whose conditions are managed by this package. Usually the
+// methods just return and replace the conditions slice in the status.
+type Object interface {
+	GetConditions() []Condition
+	SetConditions([]Condition)
+}
@@ -1,6 +1,7 @@
 package configmap
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
@@ -13,7 +14,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
-// GenerateConfigMap generates ConfigMap object as per the `Conf` struct passed
+// GenerateConfigMap generates ConfigMap object as per the `Conf`
+// struct passed
 func GenerateConfigMap(c Conf) (cm *corev1.ConfigMap, err error) {
 	var om *metav1.ObjectMeta
 	var data map[string]string
@@ -83,9 +85,38 @@ func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error
 	return true, nil
 }
 
+// MaybeUpdateWithDiff implements MaybeUpdateWithDiffFunc for Configmap
+// object. It updates the first Configmap like MaybeUpdate and returns the
+// fields changed.
+func MaybeUpdateWithDiff(original interfaces.Object, new interfaces.Object) ([]operation.FieldDiff, error) {
+	o, ok := original.(*corev1.ConfigMap)
+	if !ok {
+		return nil, errors.New("failed to assert the original object")
+	}
+	before := o.DeepCopy()
+
+	update, err := MaybeUpdate(original, new)
+	if err != nil || !update {
+		return nil, err
+	}
+
+	diff, err := operation.Diff(before, original)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute the diff of configmap")
+	}
+
+	return diff, nil
+}
+
 // Create generates the ConfigMap as per the `Conf` struct passed and
 // creates it in the cluster
 func Create(c Conf) (reconcile.Result, error) {
+	return CreateWithContext(context.Background(), c)
+}
+
+// CreateWithContext is same as Create but uses the context passed for
+// the calls to API Server and the hooks.
+func CreateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	var cm *corev1.ConfigMap
 	var err error
 	if c.GenConfigMapFunc != nil {
@@ -97,12 +128,18 @@ func Create(c Conf) (reconcile.Result, error) {
 		return reconcile.Result{}, errors.Wrap(err, "failed to generate configmap")
 	}
 
-	result, err := operation.Create(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          cm,
-		OwnerReference:  c.OwnerReference,
-		AfterCreateFunc: c.AfterCreateFunc,
+	result, err := operation.CreateWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Logger:                     c.Logger,
+		Object:                     cm,
+		OwnerReference:             c.OwnerReference,
+		AfterCreateFunc:            c.AfterCreateFunc,
+		AfterCreateWithContextFunc: c.AfterCreateWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to create configmap")
@@ -117,6 +154,12 @@ func Create(c Conf) (reconcile.Result, error) {
 // ConfigMaps, it uses `MaybeUpdate` function by default but can also
 // use `MaybeUpdateFunc` from `Conf` if passed.
 func Update(c Conf) (reconcile.Result, error) {
+	return UpdateWithContext(context.Background(), c)
+}
+
+// UpdateWithContext is same as Update but uses the context passed for
+// the calls to API Server and the hooks.
+func UpdateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	var cm *corev1.ConfigMap
 	var err error
 	if c.GenConfigMapFunc != nil {
@@ -135,13 +178,26 @@ func Update(c Conf) (reconcile.Result, error) {
 		maybeUpdateFunc = MaybeUpdate
 	}
 
-	result, err := operation.Update(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          cm,
-		ExistingObject:  &corev1.ConfigMap{},
-		MaybeUpdateFunc: maybeUpdateFunc,
-		AfterUpdateFunc: c.AfterUpdateFunc,
+	maybeUpdateWithDiffFunc := c.MaybeUpdateWithDiffFunc
+	if maybeUpdateWithDiffFunc == nil && c.MaybeUpdateFunc == nil {
+		maybeUpdateWithDiffFunc = MaybeUpdateWithDiff
+	}
+
+	result, err := operation.UpdateWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Object:                     cm,
+		ExistingObject:             &corev1.ConfigMap{},
+		MaybeUpdateFunc:            maybeUpdateFunc,
+		MaybeUpdateWithDiffFunc:    maybeUpdateWithDiffFunc,
+		DiffHook:                   c.DiffHook,
+		Logger:                     c.Logger,
+		AfterUpdateFunc:            c.AfterUpdateFunc,
+		AfterUpdateWithContextFunc: c.AfterUpdateWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to update configmap")
@@ -154,6 +210,12 @@ func Update(c Conf) (reconcile.Result, error) {
 // functions. It creates the ConfigMap object if it is not already in
 // the cluster and updates the ConfigMap if one exists.
 func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	return CreateOrUpdateWithContext(context.Background(), c)
+}
+
+// CreateOrUpdateWithContext is same as CreateOrUpdate but uses the
+// context passed for the calls to API Server and the hooks.
+func CreateOrUpdateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	var cm *corev1.ConfigMap
 	var err error
 	if c.GenConfigMapFunc != nil {
@@ -172,15 +234,29 @@ func CreateOrUpdate(c Conf) (reconcile.Result, error) {
 		maybeUpdateFunc = MaybeUpdate
 	}
 
-	result, err := operation.CreateOrUpdate(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          cm,
-		ExistingObject:  &corev1.ConfigMap{},
-		OwnerReference:  c.OwnerReference,
-		MaybeUpdateFunc: maybeUpdateFunc,
-		AfterUpdateFunc: c.AfterUpdateFunc,
-		AfterCreateFunc: c.AfterCreateFunc,
+	maybeUpdateWithDiffFunc := c.MaybeUpdateWithDiffFunc
+	if maybeUpdateWithDiffFunc == nil && c.MaybeUpdateFunc == nil {
+		maybeUpdateWithDiffFunc = MaybeUpdateWithDiff
+	}
+
+	result, err := operation.CreateOrUpdateWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Object:                     cm,
+		ExistingObject:             &corev1.ConfigMap{},
+		OwnerReference:             c.OwnerReference,
+		MaybeUpdateFunc:            maybeUpdateFunc,
+		MaybeUpdateWithDiffFunc:    maybeUpdateWithDiffFunc,
+		DiffHook:                   c.DiffHook,
+		Logger:                     c.Logger,
+		AfterUpdateFunc:            c.AfterUpdateFunc,
+		AfterUpdateWithContextFunc: c.AfterUpdateWithContextFunc,
+		AfterCreateFunc:            c.AfterCreateFunc,
+		AfterCreateWithContextFunc: c.AfterCreateWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to create or update configmap")
@@ -192,6 +268,12 @@ func CreateOrUpdate(c Conf) (reconcile.Result, error) {
 // Delete generates the ObjectMeta for ConfigMap as per the `Conf`
 // struct passed and deletes it from the cluster
 func Delete(c Conf) (reconcile.Result, error) {
+	return DeleteWithContext(context.Background(), c)
+}
+
+// DeleteWithContext is same as Delete but uses the context passed for
+// the calls to API Server and the hooks.
+func DeleteWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	om, err := meta.GenerateObjectMeta(meta.Conf{
 		Instance:           c.Instance,
 		Name:               c.Name,
@@ -204,11 +286,17 @@ func Delete(c Conf) (reconcile.Result, error) {
 		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for configmap")
 	}
 
-	result, err := operation.Delete(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          &corev1.ConfigMap{ObjectMeta: *om},
-		AfterDeleteFunc: c.AfterDeleteFunc,
+	result, err := operation.DeleteWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Logger:                     c.Logger,
+		Object:                     &corev1.ConfigMap{ObjectMeta: *om},
+		AfterDeleteFunc:            c.AfterDeleteFunc,
+		AfterDeleteWithContextFunc: c.AfterDeleteWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to delete configmap")
@@ -1,13 +1,17 @@
 package configmap_test
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ankitrgadiya/operatorlib/pkg/configmap"
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
 
+	"github.com/go-logr/logr"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
@@ -191,6 +195,34 @@ func TestMaybeUpdate(t *testing.T) {
 	})
 }
 
+func TestMaybeUpdateWithDiff(t *testing.T) {
+	t.Run("bad objects", func(t *testing.T) {
+		diff, err := configmap.MaybeUpdateWithDiff(&mocks.MockObject{}, &corev1.ConfigMap{})
+		assert.Error(t, err)
+		assert.Empty(t, diff)
+	})
+	t.Run("up-to-date configmaps", func(t *testing.T) {
+		diff, err := configmap.MaybeUpdateWithDiff(
+			&corev1.ConfigMap{Data: map[string]string{"key": "value"}},
+			&corev1.ConfigMap{Data: map[string]string{"key": "value"}},
+		)
+		assert.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+	t.Run("update data in configmaps", func(t *testing.T) {
+		existingconfigmap := &corev1.ConfigMap{Data: map[string]string{"key": "value", "old-key": "value"}}
+		newconfigmap := &corev1.ConfigMap{Data: map[string]string{"key": "new-value"}}
+
+		diff, err := configmap.MaybeUpdateWithDiff(existingconfigmap, newconfigmap)
+		assert.NoError(t, err)
+		assert.Equal(t, []operation.FieldDiff{
+			{Path: "/data/key", Old: "value", New: "new-value"},
+			{Path: "/data/old-key", Old: "value"},
+		}, diff)
+		assert.Equal(t, existingconfigmap, newconfigmap)
+	})
+}
+
 func TestCreate(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -363,3 +395,132 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+type contextKey struct{}
+
+func TestWithContext(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	ctx := context.WithValue(context.Background(), contextKey{}, "test")
+	hook := func(ctx context.Context, _ interfaces.Object, _ interfaces.Reconcile) (reconcile.Result, error) {
+		if ctx.Value(contextKey{}) != "test" {
+			return reconcile.Result{}, errors.New("context is not passed")
+		}
+		return reconcile.Result{}, nil
+	}
+
+	t.Run("create or update configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := configmap.CreateOrUpdateWithContext(ctx, configmap.Conf{
+			Name:                       "test-existing-configmap",
+			Namespace:                  "test",
+			Instance:                   i,
+			Reconcile:                  r,
+			Timeout:                    time.Second,
+			AfterUpdateWithContextFunc: hook,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("delete configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := configmap.DeleteWithContext(ctx, configmap.Conf{
+			Name:                       "test-existing-configmap",
+			Namespace:                  "test",
+			Instance:                   i,
+			Reconcile:                  r,
+			AfterDeleteWithContextFunc: hook,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("hook fails without context", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := configmap.CreateWithContext(context.Background(), configmap.Conf{
+			Name:                       "test-configmap",
+			Namespace:                  "test",
+			Instance:                   i,
+			Reconcile:                  r,
+			AfterCreateWithContextFunc: hook,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	i, r := mockSetup(controller)
+	client := r.GetClient()
+	plan := &operation.Plan{}
+
+	_, err := configmap.CreateOrUpdate(configmap.Conf{
+		Name:      "test-existing-configmap",
+		Namespace: "test",
+		Instance:  i,
+		Reconcile: r,
+		GenDataFunc: func(interfaces.Object) (map[string]string, error) {
+			return map[string]string{"key1": "new-value1"}, nil
+		},
+		DryRun: operation.ClientDryRun,
+		Plan:   plan,
+	})
+	assert.NoError(t, err)
+
+	cm := &corev1.ConfigMap{}
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, cm)
+	assert.NoError(t, err)
+	assert.Equal(t, "value1", cm.Data["key1"])
+
+	assert.Len(t, plan.Entries, 1)
+	assert.Equal(t, operation.ActionUpdate, plan.Entries[0].Action)
+	assert.Contains(t, plan.Entries[0].Diff, operation.FieldDiff{Path: "/data/key1", Old: "value1", New: "new-value1"})
+}
+
+func TestEvents(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	i, mr := mockSetup(controller)
+	r := mocks.NewRecorderReconcile(mr, 10)
+	reasons := operation.EventReasons{Created: "ConfigMapCreated", Deleted: "ConfigMapDeleted"}
+
+	_, err := configmap.Create(configmap.Conf{Instance: i, Reconcile: r, Name: "test-configmap", Namespace: "test", EventReasons: reasons})
+	assert.NoError(t, err)
+	_, err = configmap.Delete(configmap.Conf{Instance: i, Reconcile: r, Name: "test-existing-configmap", Namespace: "test", EventReasons: reasons})
+	assert.NoError(t, err)
+
+	if assert.Len(t, r.Recorder.Events, 2) {
+		assert.Equal(t, "Normal ConfigMapCreated Created ConfigMap test-configmap", <-r.Recorder.Events)
+		assert.Equal(t, "Normal ConfigMapDeleted Deleted ConfigMap test-existing-configmap", <-r.Recorder.Events)
+	}
+}
+
+// testLogger records the messages logged at any verbosity level.
+type testLogger struct {
+	logr.Logger
+	messages []string
+}
+
+func (l *testLogger) V(int) logr.InfoLogger { return l }
+
+func (l *testLogger) Enabled() bool { return true }
+
+func (l *testLogger) Info(msg string, _ ...interface{}) {
+	l.messages = append(l.messages, msg)
+}
+
+func TestLogging(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	i, r := mockSetup(controller)
+	logger := &testLogger{}
+
+	_, err := configmap.Create(configmap.Conf{Instance: i, Reconcile: r, Name: "test-configmap", Namespace: "test", Logger: logger})
+	assert.NoError(t, err)
+	_, err = configmap.Delete(configmap.Conf{Instance: i, Reconcile: r, Name: "test-configmap", Namespace: "test", Logger: logger})
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"object created", "object deleted"}, logger.messages)
+}
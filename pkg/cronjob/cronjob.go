@@ -0,0 +1,281 @@
+package cronjob
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateCronJob generates CronJob object as per the `Conf` struct
+// passed. Like the Job package, if the generated Pod template does
+// not specify the restart policy, `Never` is used.
+func GenerateCronJob(c Conf) (cj *batchv1beta1.CronJob, err error) {
+	var om *metav1.ObjectMeta
+	var template corev1.PodTemplateSpec
+	var backoffLimit *int32
+	var activeDeadlineSeconds *int64
+	var schedule string
+	var concurrencyPolicy batchv1beta1.ConcurrencyPolicy
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenPodTemplateSpecFunc != nil {
+		var t *corev1.PodTemplateSpec
+		t, err = c.GenPodTemplateSpecFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate pod template")
+		}
+		if t != nil {
+			template = *t
+		}
+	}
+
+	if c.GenContainersFunc != nil {
+		template.Spec.Containers, err = c.GenContainersFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate containers")
+		}
+	}
+
+	if c.GenBackoffLimitFunc != nil {
+		backoffLimit, err = c.GenBackoffLimitFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate backoff limit")
+		}
+	}
+
+	if c.GenActiveDeadlineSecondsFunc != nil {
+		activeDeadlineSeconds, err = c.GenActiveDeadlineSecondsFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate active deadline seconds")
+		}
+	}
+
+	if c.GenScheduleFunc != nil {
+		schedule, err = c.GenScheduleFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate schedule")
+		}
+	}
+
+	if c.GenConcurrencyPolicyFunc != nil {
+		concurrencyPolicy, err = c.GenConcurrencyPolicyFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate concurrency policy")
+		}
+	}
+
+	if template.Spec.RestartPolicy == "" {
+		template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	cj = &batchv1beta1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "CronJob",
+			APIVersion: "batch/v1beta1",
+		},
+		ObjectMeta: *om,
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:          schedule,
+			ConcurrencyPolicy: concurrencyPolicy,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					Template:              template,
+					BackoffLimit:          backoffLimit,
+					ActiveDeadlineSeconds: activeDeadlineSeconds,
+				},
+			},
+		},
+	}
+
+	return cj, nil
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for CronJob object. It
+// compares the two CronJobs being passed and update the first one if
+// required. Unlike Job, the Job template of CronJob can be updated
+// and only affects the Jobs created afterwards. Concurrency policy is
+// defaulted by API Server so it is only compared if set. Only the
+// fields set in the generated Job template are compared.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	ocj, ok := original.(*batchv1beta1.CronJob)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	ncj, ok := new.(*batchv1beta1.CronJob)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	update := false
+
+	if ocj.Spec.Schedule != ncj.Spec.Schedule {
+		ocj.Spec.Schedule = ncj.Spec.Schedule
+		update = true
+	}
+
+	if ncj.Spec.ConcurrencyPolicy != "" && ocj.Spec.ConcurrencyPolicy != ncj.Spec.ConcurrencyPolicy {
+		ocj.Spec.ConcurrencyPolicy = ncj.Spec.ConcurrencyPolicy
+		update = true
+	}
+
+	if !operation.DeepDerivative(ncj.Spec.JobTemplate, ocj.Spec.JobTemplate) {
+		ocj.Spec.JobTemplate = ncj.Spec.JobTemplate
+		update = true
+	}
+
+	return update, nil
+}
+
+// Create generates the CronJob as per the `Conf` struct passed and
+// creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var cj *batchv1beta1.CronJob
+	var err error
+	if c.GenCronJobFunc != nil {
+		cj, err = c.GenCronJobFunc(c)
+	} else {
+		cj, err = GenerateCronJob(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate cronjob")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          cj,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create cronjob")
+	}
+
+	return result, nil
+}
+
+// Update generates the CronJob as per the `Conf` struct passed and
+// compares it with the in-cluster version. If required, it updates
+// the in-cluster CronJob with the changes. For comparing the
+// CronJobs, it uses `MaybeUpdate` function by default but can also
+// use `MaybeUpdateFunc` from `Conf` if passed.
+func Update(c Conf) (reconcile.Result, error) {
+	var cj *batchv1beta1.CronJob
+	var err error
+	if c.GenCronJobFunc != nil {
+		cj, err = c.GenCronJobFunc(c)
+	} else {
+		cj, err = GenerateCronJob(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate cronjob")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          cj,
+		ExistingObject:  &batchv1beta1.CronJob{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update cronjob")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update`
+// functions. It creates the CronJob object if it is not already in
+// the cluster and updates the CronJob if one exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var cj *batchv1beta1.CronJob
+	var err error
+	if c.GenCronJobFunc != nil {
+		cj, err = c.GenCronJobFunc(c)
+	} else {
+		cj, err = GenerateCronJob(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate cronjob")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          cj,
+		ExistingObject:  &batchv1beta1.CronJob{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update cronjob")
+	}
+
+	return result, nil
+}
+
+// Delete generates the ObjectMeta for CronJob as per the `Conf`
+// struct passed and deletes it from the cluster
+func Delete(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for cronjob")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &batchv1beta1.CronJob{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete cronjob")
+	}
+
+	return result, nil
+}
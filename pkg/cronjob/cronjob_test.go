@@ -0,0 +1,400 @@
+package cronjob_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/cronjob"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+
+	cj := &batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-existing-cronjob", Namespace: "test"},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:          "*/5 * * * *",
+			ConcurrencyPolicy: batchv1beta1.AllowConcurrent,
+			JobTemplate: batchv1beta1.JobTemplateSpec{Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{{Name: "test", Image: "test:v1"}},
+				}},
+			}},
+		},
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{cj}...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+func TestGenerateCronJob(t *testing.T) {
+	t.Run("generate empty cronjob", func(t *testing.T) {
+		expected := &batchv1beta1.CronJob{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CronJob",
+				APIVersion: "batch/v1beta1",
+			},
+			Spec: batchv1beta1.CronJobSpec{JobTemplate: batchv1beta1.JobTemplateSpec{Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{RestartPolicy: corev1.RestartPolicyNever}},
+			}}},
+		}
+
+		result, err := cronjob.GenerateCronJob(cronjob.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := cronjob.GenerateCronJob(cronjob.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate pod template", func(t *testing.T) {
+		result, err := cronjob.GenerateCronJob(cronjob.Conf{
+			GenPodTemplateSpecFunc: func(interfaces.Object) (*corev1.PodTemplateSpec, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate containers", func(t *testing.T) {
+		result, err := cronjob.GenerateCronJob(cronjob.Conf{
+			GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate backoff limit", func(t *testing.T) {
+		result, err := cronjob.GenerateCronJob(cronjob.Conf{
+			GenBackoffLimitFunc: func(interfaces.Object) (*int32, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate active deadline seconds", func(t *testing.T) {
+		result, err := cronjob.GenerateCronJob(cronjob.Conf{
+			GenActiveDeadlineSecondsFunc: func(interfaces.Object) (*int64, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate schedule", func(t *testing.T) {
+		result, err := cronjob.GenerateCronJob(cronjob.Conf{
+			GenScheduleFunc: func(interfaces.Object) (string, error) { return "", errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate concurrency policy", func(t *testing.T) {
+		result, err := cronjob.GenerateCronJob(cronjob.Conf{
+			GenConcurrencyPolicyFunc: func(interfaces.Object) (batchv1beta1.ConcurrencyPolicy, error) {
+				return "", errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate cronjob", func(t *testing.T) {
+		expected := &batchv1beta1.CronJob{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "CronJob",
+				APIVersion: "batch/v1beta1",
+			},
+			Spec: batchv1beta1.CronJobSpec{
+				Schedule:          "@hourly",
+				ConcurrencyPolicy: batchv1beta1.ForbidConcurrent,
+				JobTemplate: batchv1beta1.JobTemplateSpec{Spec: batchv1.JobSpec{
+					BackoffLimit:          int32Ptr(2),
+					ActiveDeadlineSeconds: int64Ptr(60),
+					Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+						RestartPolicy: corev1.RestartPolicyNever,
+						Containers:    []corev1.Container{{Name: "test", Image: "test:v1"}},
+					}},
+				}},
+			},
+		}
+
+		result, err := cronjob.GenerateCronJob(cronjob.Conf{
+			GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) {
+				return []corev1.Container{{Name: "test", Image: "test:v1"}}, nil
+			},
+			GenBackoffLimitFunc:          func(interfaces.Object) (*int32, error) { return int32Ptr(2), nil },
+			GenActiveDeadlineSecondsFunc: func(interfaces.Object) (*int64, error) { return int64Ptr(60), nil },
+			GenScheduleFunc:              func(interfaces.Object) (string, error) { return "@hourly", nil },
+			GenConcurrencyPolicyFunc: func(interfaces.Object) (batchv1beta1.ConcurrencyPolicy, error) {
+				return batchv1beta1.ForbidConcurrent, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := cronjob.MaybeUpdate(&mocks.MockObject{}, &batchv1beta1.CronJob{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := cronjob.MaybeUpdate(&batchv1beta1.CronJob{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := cronjob.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare cronjobs", func(t *testing.T) {
+		t.Run("empty cronjobs", func(t *testing.T) {
+			result, err := cronjob.MaybeUpdate(&batchv1beta1.CronJob{}, &batchv1beta1.CronJob{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different schedule", func(t *testing.T) {
+			existingCronJob := &batchv1beta1.CronJob{Spec: batchv1beta1.CronJobSpec{Schedule: "@hourly"}}
+			newCronJob := &batchv1beta1.CronJob{Spec: batchv1beta1.CronJobSpec{Schedule: "@daily"}}
+
+			result, err := cronjob.MaybeUpdate(existingCronJob, newCronJob)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingCronJob, newCronJob)
+		})
+		t.Run("defaulted concurrency policy", func(t *testing.T) {
+			existingCronJob := &batchv1beta1.CronJob{Spec: batchv1beta1.CronJobSpec{ConcurrencyPolicy: batchv1beta1.AllowConcurrent}}
+			newCronJob := &batchv1beta1.CronJob{}
+
+			result, err := cronjob.MaybeUpdate(existingCronJob, newCronJob)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different concurrency policy", func(t *testing.T) {
+			existingCronJob := &batchv1beta1.CronJob{Spec: batchv1beta1.CronJobSpec{ConcurrencyPolicy: batchv1beta1.AllowConcurrent}}
+			newCronJob := &batchv1beta1.CronJob{Spec: batchv1beta1.CronJobSpec{ConcurrencyPolicy: batchv1beta1.ReplaceConcurrent}}
+
+			result, err := cronjob.MaybeUpdate(existingCronJob, newCronJob)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingCronJob, newCronJob)
+		})
+		t.Run("different job template", func(t *testing.T) {
+			existingCronJob := &batchv1beta1.CronJob{Spec: batchv1beta1.CronJobSpec{JobTemplate: batchv1beta1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{BackoffLimit: int32Ptr(6)},
+			}}}
+			newCronJob := &batchv1beta1.CronJob{Spec: batchv1beta1.CronJobSpec{JobTemplate: batchv1beta1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{BackoffLimit: int32Ptr(2)},
+			}}}
+
+			result, err := cronjob.MaybeUpdate(existingCronJob, newCronJob)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingCronJob, newCronJob)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := cronjob.Create(cronjob.Conf{GenScheduleFunc: func(interfaces.Object) (string, error) {
+			return "", errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := cronjob.Create(cronjob.Conf{GenCronJobFunc: func(cronjob.Conf) (*batchv1beta1.CronJob, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := cronjob.Create(cronjob.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create cronjob", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := cronjob.Create(cronjob.Conf{
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := cronjob.Update(cronjob.Conf{GenScheduleFunc: func(interfaces.Object) (string, error) {
+			return "", errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := cronjob.Update(cronjob.Conf{GenCronJobFunc: func(cronjob.Conf) (*batchv1beta1.CronJob, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := cronjob.Update(cronjob.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("custom maybeupdate function", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := cronjob.Update(cronjob.Conf{
+			Name:            "test-existing-cronjob",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			MaybeUpdateFunc: func(interfaces.Object, interfaces.Object) (bool, error) { return true, nil },
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("update cronjob", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := cronjob.Update(cronjob.Conf{
+			Name:            "test-existing-cronjob",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			GenScheduleFunc: func(interfaces.Object) (string, error) { return "@hourly", nil },
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := cronjob.CreateOrUpdate(cronjob.Conf{GenScheduleFunc: func(interfaces.Object) (string, error) {
+			return "", errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := cronjob.CreateOrUpdate(cronjob.Conf{GenCronJobFunc: func(cronjob.Conf) (*batchv1beta1.CronJob, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := cronjob.CreateOrUpdate(cronjob.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := cronjob.CreateOrUpdate(cronjob.Conf{
+			Name:      "test-existing-cronjob",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update cronjob", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := cronjob.CreateOrUpdate(cronjob.Conf{
+			Name:      "test-existing-cronjob",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := cronjob.Delete(cronjob.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to delete", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := cronjob.Delete(cronjob.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("delete cronjob", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := cronjob.Delete(cronjob.Conf{
+			Name:      "test-existing-cronjob",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,3 @@
+// Package cronjob provides functions for manipulating CronJob object
+// in Kubernetes cluster.
+package cronjob
@@ -0,0 +1,49 @@
+package cronjob_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/cronjob"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	result, err := cronjob.CreateOrUpdate(cronjob.Conf{
+		// Instance is the pointer to owner object under which
+		// CronJob is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the CronJob object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated CronJob.
+		Name: "cronjob-test",
+		// GenScheduleFunc is the function that generates the
+		// schedule of the CronJob in Cron format.
+		GenScheduleFunc: func(interfaces.Object) (string, error) {
+			return "@daily", nil
+		},
+		// GenConcurrencyPolicyFunc is the function that generates
+		// the concurrency policy of the CronJob.
+		GenConcurrencyPolicyFunc: func(interfaces.Object) (batchv1beta1.ConcurrencyPolicy, error) {
+			return batchv1beta1.ForbidConcurrent, nil
+		},
+		// GenContainersFunc is the function that generates the
+		// containers for the Pod template of the Jobs.
+		GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) {
+			return []corev1.Container{{Name: "backup", Image: "backup:v1"}}, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
@@ -0,0 +1,97 @@
+package cronjob
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GenCronJobFunc defines a function which generates CronJob
+type GenCronJobFunc func(Conf) (*batchv1beta1.CronJob, error)
+
+// GenPodTemplateSpecFunc defines a function which generates
+// PodTemplateSpec for the Jobs created by the CronJob
+type GenPodTemplateSpecFunc func(interfaces.Object) (*corev1.PodTemplateSpec, error)
+
+// GenContainersFunc defines a function which generates slice of
+// Container for the Pod template of the Jobs
+type GenContainersFunc func(interfaces.Object) ([]corev1.Container, error)
+
+// GenBackoffLimitFunc defines a function which generates number of
+// retries before marking the Jobs failed
+type GenBackoffLimitFunc func(interfaces.Object) (*int32, error)
+
+// GenActiveDeadlineSecondsFunc defines a function which generates the
+// duration in seconds for which the Jobs may be active
+type GenActiveDeadlineSecondsFunc func(interfaces.Object) (*int64, error)
+
+// GenScheduleFunc defines a function which generates the schedule of
+// the CronJob in Cron format
+type GenScheduleFunc func(interfaces.Object) (string, error)
+
+// GenConcurrencyPolicyFunc defines a function which generates the
+// policy for concurrent executions of the Jobs
+type GenConcurrencyPolicyFunc func(interfaces.Object) (batchv1beta1.ConcurrencyPolicy, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on CronJob objects.
+type Conf struct {
+	// Instance is the Owner object which manages the CronJob
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the CronJob
+	Name string
+	// Namespace of the CronJob
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on CronJob before creating it in cluster
+	OwnerReference bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for CronJob update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the CronJob
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the CronJob
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the CronJob
+	operation.AfterDeleteFunc
+	// GenCronJobFunc defines a function to generate the CronJob
+	// object. The package comes with default cronjob generator
+	// function which is used by operation functions. By specifying
+	// this field, user can override the default function with a
+	// custom one.
+	GenCronJobFunc
+	// GenPodTemplateSpecFunc defines a function to generate the Pod
+	// template for the Jobs
+	GenPodTemplateSpecFunc
+	// GenContainersFunc defines a function to generate containers
+	// for the Pod template. If specified, the generated containers
+	// replace the ones in the generated Pod template.
+	GenContainersFunc
+	// GenBackoffLimitFunc defines a function to generate backoff
+	// limit for the Jobs
+	GenBackoffLimitFunc
+	// GenActiveDeadlineSecondsFunc defines a function to generate
+	// active deadline for the Jobs
+	GenActiveDeadlineSecondsFunc
+	// GenScheduleFunc defines a function to generate schedule for
+	// the CronJob
+	GenScheduleFunc
+	// GenConcurrencyPolicyFunc defines a function to generate
+	// concurrency policy for the CronJob
+	GenConcurrencyPolicyFunc
+}
@@ -0,0 +1,278 @@
+package daemonset
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateDaemonSet generates DaemonSet object as per the `Conf`
+// struct passed. Node selector and tolerations are set on the Pod
+// template. If the generated Pod template does not have any labels
+// then the MatchLabels of the generated selector are used as labels
+// for the Pod template, since those are required to match anyway.
+func GenerateDaemonSet(c Conf) (ds *appsv1.DaemonSet, err error) {
+	var om *metav1.ObjectMeta
+	var template corev1.PodTemplateSpec
+	var updateStrategy appsv1.DaemonSetUpdateStrategy
+	var selector *metav1.LabelSelector
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenPodTemplateSpecFunc != nil {
+		var t *corev1.PodTemplateSpec
+		t, err = c.GenPodTemplateSpecFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate pod template")
+		}
+		if t != nil {
+			template = *t
+		}
+	}
+
+	if c.GenContainersFunc != nil {
+		template.Spec.Containers, err = c.GenContainersFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate containers")
+		}
+	}
+
+	if c.GenNodeSelectorFunc != nil {
+		template.Spec.NodeSelector, err = c.GenNodeSelectorFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate node selector")
+		}
+	}
+
+	if c.GenTolerationsFunc != nil {
+		template.Spec.Tolerations, err = c.GenTolerationsFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate tolerations")
+		}
+	}
+
+	if c.GenUpdateStrategyFunc != nil {
+		updateStrategy, err = c.GenUpdateStrategyFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate update strategy")
+		}
+	}
+
+	if c.GenSelectorFunc != nil {
+		selector, err = c.GenSelectorFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate selector")
+		}
+	}
+
+	if selector != nil && template.Labels == nil && selector.MatchLabels != nil {
+		template.Labels = make(map[string]string, len(selector.MatchLabels))
+		for key, value := range selector.MatchLabels {
+			template.Labels[key] = value
+		}
+	}
+
+	ds = &appsv1.DaemonSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "DaemonSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: *om,
+		Spec: appsv1.DaemonSetSpec{
+			Selector:       selector,
+			Template:       template,
+			UpdateStrategy: updateStrategy,
+		},
+	}
+
+	return ds, nil
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for DaemonSet object. It
+// compares the two DaemonSets being passed and update the first one
+// if required. Selector is immutable in apps/v1, so it returns
+// operation.ImmutableFieldError if that is different. API Server
+// fills in a lot of defaults in the Pod template and the update
+// strategy, so the comparison only considers the fields set in the
+// generated DaemonSet.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	ods, ok := original.(*appsv1.DaemonSet)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	nds, ok := new.(*appsv1.DaemonSet)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	if !equality.Semantic.DeepEqual(ods.Spec.Selector, nds.Spec.Selector) {
+		return false, operation.NewImmutableFieldError("DaemonSet", "spec.selector")
+	}
+
+	update := false
+
+	if !equality.Semantic.DeepDerivative(nds.Spec.UpdateStrategy, ods.Spec.UpdateStrategy) {
+		ods.Spec.UpdateStrategy = nds.Spec.UpdateStrategy
+		update = true
+	}
+
+	if !operation.DeepDerivative(nds.Spec.Template, ods.Spec.Template) {
+		ods.Spec.Template = nds.Spec.Template
+		update = true
+	}
+
+	return update, nil
+}
+
+// Create generates the DaemonSet as per the `Conf` struct passed and
+// creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var ds *appsv1.DaemonSet
+	var err error
+	if c.GenDaemonSetFunc != nil {
+		ds, err = c.GenDaemonSetFunc(c)
+	} else {
+		ds, err = GenerateDaemonSet(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate daemonset")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          ds,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create daemonset")
+	}
+
+	return result, nil
+}
+
+// Update generates the DaemonSet as per the `Conf` struct passed and
+// compares it with the in-cluster version. If required, it updates
+// the in-cluster DaemonSet with the changes. For comparing the
+// DaemonSets, it uses `MaybeUpdate` function by default but can also
+// use `MaybeUpdateFunc` from `Conf` if passed.
+func Update(c Conf) (reconcile.Result, error) {
+	var ds *appsv1.DaemonSet
+	var err error
+	if c.GenDaemonSetFunc != nil {
+		ds, err = c.GenDaemonSetFunc(c)
+	} else {
+		ds, err = GenerateDaemonSet(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate daemonset")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          ds,
+		ExistingObject:  &appsv1.DaemonSet{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update daemonset")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update`
+// functions. It creates the DaemonSet object if it is not already in
+// the cluster and updates the DaemonSet if one exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var ds *appsv1.DaemonSet
+	var err error
+	if c.GenDaemonSetFunc != nil {
+		ds, err = c.GenDaemonSetFunc(c)
+	} else {
+		ds, err = GenerateDaemonSet(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate daemonset")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          ds,
+		ExistingObject:  &appsv1.DaemonSet{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update daemonset")
+	}
+
+	return result, nil
+}
+
+// Delete generates the ObjectMeta for DaemonSet as per the `Conf`
+// struct passed and deletes it from the cluster
+func Delete(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for daemonset")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &appsv1.DaemonSet{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete daemonset")
+	}
+
+	return result, nil
+}
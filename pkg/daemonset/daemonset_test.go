@@ -0,0 +1,480 @@
+package daemonset_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/daemonset"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func testSelector(interfaces.Object) (*metav1.LabelSelector, error) {
+	return &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, nil
+}
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+
+	d := &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-existing-daemonset", Namespace: "test"},
+		Spec: appsv1.DaemonSetSpec{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v1"}},
+				},
+			},
+		},
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{d}...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+func TestGenerateDaemonSet(t *testing.T) {
+	t.Run("generate empty daemonset", func(t *testing.T) {
+		expected := &appsv1.DaemonSet{TypeMeta: metav1.TypeMeta{
+			Kind:       "DaemonSet",
+			APIVersion: "apps/v1",
+		}}
+
+		result, err := daemonset.GenerateDaemonSet(daemonset.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := daemonset.GenerateDaemonSet(daemonset.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate pod template", func(t *testing.T) {
+		result, err := daemonset.GenerateDaemonSet(daemonset.Conf{
+			GenPodTemplateSpecFunc: func(interfaces.Object) (*corev1.PodTemplateSpec, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate containers", func(t *testing.T) {
+		result, err := daemonset.GenerateDaemonSet(daemonset.Conf{
+			GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate node selector", func(t *testing.T) {
+		result, err := daemonset.GenerateDaemonSet(daemonset.Conf{
+			GenNodeSelectorFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate tolerations", func(t *testing.T) {
+		result, err := daemonset.GenerateDaemonSet(daemonset.Conf{
+			GenTolerationsFunc: func(interfaces.Object) ([]corev1.Toleration, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate update strategy", func(t *testing.T) {
+		result, err := daemonset.GenerateDaemonSet(daemonset.Conf{
+			GenUpdateStrategyFunc: func(interfaces.Object) (appsv1.DaemonSetUpdateStrategy, error) {
+				return appsv1.DaemonSetUpdateStrategy{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate selector", func(t *testing.T) {
+		result, err := daemonset.GenerateDaemonSet(daemonset.Conf{
+			GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate daemonset with containers, node selector, tolerations, update strategy and selector", func(t *testing.T) {
+		expected := &appsv1.DaemonSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "DaemonSet",
+				APIVersion: "apps/v1",
+			},
+			Spec: appsv1.DaemonSetSpec{
+				Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec: corev1.PodSpec{
+						ServiceAccountName: "test",
+						Containers:         []corev1.Container{{Name: "test", Image: "test:v1"}},
+						NodeSelector:       map[string]string{"kubernetes.io/os": "linux"},
+						Tolerations:        []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					},
+				},
+			},
+		}
+
+		result, err := daemonset.GenerateDaemonSet(daemonset.Conf{
+			GenPodTemplateSpecFunc: func(interfaces.Object) (*corev1.PodTemplateSpec, error) {
+				return &corev1.PodTemplateSpec{Spec: corev1.PodSpec{ServiceAccountName: "test"}}, nil
+			},
+			GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) {
+				return []corev1.Container{{Name: "test", Image: "test:v1"}}, nil
+			},
+			GenNodeSelectorFunc: func(interfaces.Object) (map[string]string, error) {
+				return map[string]string{"kubernetes.io/os": "linux"}, nil
+			},
+			GenTolerationsFunc: func(interfaces.Object) ([]corev1.Toleration, error) {
+				return []corev1.Toleration{{Operator: corev1.TolerationOpExists}}, nil
+			},
+			GenUpdateStrategyFunc: func(interfaces.Object) (appsv1.DaemonSetUpdateStrategy, error) {
+				return appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType}, nil
+			},
+			GenSelectorFunc: testSelector,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+	t.Run("pod template labels are not overridden by selector", func(t *testing.T) {
+		result, err := daemonset.GenerateDaemonSet(daemonset.Conf{
+			GenPodTemplateSpecFunc: func(interfaces.Object) (*corev1.PodTemplateSpec, error) {
+				return &corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test", "tier": "web"}}}, nil
+			},
+			GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+				return &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"app": "test", "tier": "web"}, result.Spec.Template.Labels)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := daemonset.MaybeUpdate(&mocks.MockObject{}, &appsv1.DaemonSet{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := daemonset.MaybeUpdate(&appsv1.DaemonSet{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := daemonset.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare daemonsets", func(t *testing.T) {
+		t.Run("empty daemonsets", func(t *testing.T) {
+			result, err := daemonset.MaybeUpdate(&appsv1.DaemonSet{}, &appsv1.DaemonSet{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("defaulted fields are ignored", func(t *testing.T) {
+			existingDaemonSet := &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{
+				UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: appsv1.RollingUpdateDaemonSetStrategyType},
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyAlways,
+					DNSPolicy:     corev1.DNSClusterFirst,
+					Containers: []corev1.Container{{
+						Name:                     "test",
+						Image:                    "test:v1",
+						ImagePullPolicy:          corev1.PullIfNotPresent,
+						TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+						TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+					}},
+				}},
+			}}
+			newDaemonSet := &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v1"}},
+				}},
+			}}
+
+			result, err := daemonset.MaybeUpdate(existingDaemonSet, newDaemonSet)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different selector", func(t *testing.T) {
+			existingDaemonSet := &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			}}
+			newDaemonSet := &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}},
+			}}
+
+			result, err := daemonset.MaybeUpdate(existingDaemonSet, newDaemonSet)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+		t.Run("different update strategy", func(t *testing.T) {
+			existingDaemonSet := &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{
+				UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: appsv1.RollingUpdateDaemonSetStrategyType},
+			}}
+			newDaemonSet := &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{
+				UpdateStrategy: appsv1.DaemonSetUpdateStrategy{Type: appsv1.OnDeleteDaemonSetStrategyType},
+			}}
+
+			result, err := daemonset.MaybeUpdate(existingDaemonSet, newDaemonSet)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingDaemonSet, newDaemonSet)
+		})
+		t.Run("different container image", func(t *testing.T) {
+			existingDaemonSet := &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v1"}},
+				}},
+			}}
+			newDaemonSet := &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v2"}},
+				}},
+			}}
+
+			result, err := daemonset.MaybeUpdate(existingDaemonSet, newDaemonSet)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingDaemonSet, newDaemonSet)
+		})
+		t.Run("removed container and volume", func(t *testing.T) {
+			existingDaemonSet := &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "test", Image: "test:v1", VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}},
+						{Name: "sidecar", Image: "sidecar:v1"},
+					},
+					Volumes: []corev1.Volume{{Name: "data"}},
+				}},
+			}}
+			newDaemonSet := &appsv1.DaemonSet{Spec: appsv1.DaemonSetSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v1"}},
+				}},
+			}}
+
+			result, err := daemonset.MaybeUpdate(existingDaemonSet, newDaemonSet)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingDaemonSet, newDaemonSet)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := daemonset.Create(daemonset.Conf{GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := daemonset.Create(daemonset.Conf{GenDaemonSetFunc: func(daemonset.Conf) (*appsv1.DaemonSet, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := daemonset.Create(daemonset.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create daemonset", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := daemonset.Create(daemonset.Conf{
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := daemonset.Update(daemonset.Conf{GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := daemonset.Update(daemonset.Conf{GenDaemonSetFunc: func(daemonset.Conf) (*appsv1.DaemonSet, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := daemonset.Update(daemonset.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("custom maybeupdate function", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := daemonset.Update(daemonset.Conf{
+			Name:            "test-existing-daemonset",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			MaybeUpdateFunc: func(interfaces.Object, interfaces.Object) (bool, error) { return true, nil },
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("change selector", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := daemonset.Update(daemonset.Conf{
+			Name:      "test-existing-daemonset",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+			GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+				return &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}, nil
+			},
+		})
+		assert.True(t, operation.IsImmutableFieldError(err))
+	})
+	t.Run("update daemonset", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := daemonset.Update(daemonset.Conf{
+			Name:            "test-existing-daemonset",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			GenSelectorFunc: testSelector,
+			GenTolerationsFunc: func(interfaces.Object) ([]corev1.Toleration, error) {
+				return []corev1.Toleration{{Operator: corev1.TolerationOpExists}}, nil
+			},
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := daemonset.CreateOrUpdate(daemonset.Conf{GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := daemonset.CreateOrUpdate(daemonset.Conf{GenDaemonSetFunc: func(daemonset.Conf) (*appsv1.DaemonSet, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := daemonset.CreateOrUpdate(daemonset.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := daemonset.CreateOrUpdate(daemonset.Conf{
+			Name:      "test-existing-daemonset",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update daemonset", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := daemonset.CreateOrUpdate(daemonset.Conf{
+			Name:            "test-existing-daemonset",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			GenSelectorFunc: testSelector,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := daemonset.Delete(daemonset.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to delete", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := daemonset.Delete(daemonset.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("delete daemonset", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := daemonset.Delete(daemonset.Conf{
+			Name:      "test-existing-daemonset",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
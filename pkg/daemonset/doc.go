@@ -0,0 +1,3 @@
+// Package daemonset provides functions for manipulating DaemonSet
+// object in Kubernetes cluster.
+package daemonset
@@ -0,0 +1,58 @@
+package daemonset_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/daemonset"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	result, err := daemonset.CreateOrUpdate(daemonset.Conf{
+		// Instance is the pointer to owner object under which
+		// DaemonSet is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the DaemonSet object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated DaemonSet.
+		Name: "ds-test",
+		// GenSelectorFunc is the function that generates the label
+		// selector for the DaemonSet. This field is immutable.
+		GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return &metav1.LabelSelector{MatchLabels: map[string]string{"app": "agent"}}, nil
+		},
+		// GenNodeSelectorFunc is the function that generates the node
+		// selector to restrict the nodes the Pods are scheduled on.
+		GenNodeSelectorFunc: func(interfaces.Object) (map[string]string, error) {
+			return map[string]string{"kubernetes.io/os": "linux"}, nil
+		},
+		// GenTolerationsFunc is the function that generates the
+		// tolerations, for example to run on tainted master nodes.
+		GenTolerationsFunc: func(interfaces.Object) ([]corev1.Toleration, error) {
+			return []corev1.Toleration{{
+				Key:      "node-role.kubernetes.io/master",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			}}, nil
+		},
+		// GenContainersFunc is the function that generates the
+		// containers for the Pod template.
+		GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) {
+			return []corev1.Container{{Name: "agent", Image: "fluentd:v1.7"}}, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
@@ -0,0 +1,100 @@
+package daemonset
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GenDaemonSetFunc defines a function which generates DaemonSet
+type GenDaemonSetFunc func(Conf) (*appsv1.DaemonSet, error)
+
+// GenPodTemplateSpecFunc defines a function which generates
+// PodTemplateSpec for the DaemonSet
+type GenPodTemplateSpecFunc func(interfaces.Object) (*corev1.PodTemplateSpec, error)
+
+// GenContainersFunc defines a function which generates slice of
+// Container for the Pod template of the DaemonSet
+type GenContainersFunc func(interfaces.Object) ([]corev1.Container, error)
+
+// GenNodeSelectorFunc defines a function which generates node
+// selector for the Pod template of the DaemonSet
+type GenNodeSelectorFunc func(interfaces.Object) (map[string]string, error)
+
+// GenTolerationsFunc defines a function which generates slice of
+// Toleration for the Pod template of the DaemonSet
+type GenTolerationsFunc func(interfaces.Object) ([]corev1.Toleration, error)
+
+// GenUpdateStrategyFunc defines a function which generates the
+// strategy used to replace old Pods by new ones
+type GenUpdateStrategyFunc func(interfaces.Object) (appsv1.DaemonSetUpdateStrategy, error)
+
+// GenSelectorFunc defines a function which generates label selector
+// for the DaemonSet
+type GenSelectorFunc func(interfaces.Object) (*metav1.LabelSelector, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on DaemonSet objects.
+type Conf struct {
+	// Instance is the Owner object which manages the DaemonSet
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the DaemonSet
+	Name string
+	// Namespace of the DaemonSet
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on DaemonSet before creating it in cluster
+	OwnerReference bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for DaemonSet update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the DaemonSet
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the DaemonSet
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the DaemonSet
+	operation.AfterDeleteFunc
+	// GenDaemonSetFunc defines a function to generate the DaemonSet
+	// object. The package comes with default daemonset generator
+	// function which is used by operation functions. By specifying
+	// this field, user can override the default function with a
+	// custom one.
+	GenDaemonSetFunc
+	// GenPodTemplateSpecFunc defines a function to generate the Pod
+	// template for the DaemonSet
+	GenPodTemplateSpecFunc
+	// GenContainersFunc defines a function to generate containers
+	// for the Pod template. If specified, the generated containers
+	// replace the ones in the generated Pod template.
+	GenContainersFunc
+	// GenNodeSelectorFunc defines a function to generate node
+	// selector for the Pod template. If specified, the generated
+	// node selector replaces the one in the generated Pod template.
+	GenNodeSelectorFunc
+	// GenTolerationsFunc defines a function to generate tolerations
+	// for the Pod template. If specified, the generated tolerations
+	// replace the ones in the generated Pod template.
+	GenTolerationsFunc
+	// GenUpdateStrategyFunc defines a function to generate the
+	// update strategy for the DaemonSet
+	GenUpdateStrategyFunc
+	// GenSelectorFunc defines a function to generate label selector
+	// for the DaemonSet
+	GenSelectorFunc
+}
@@ -0,0 +1,279 @@
+package deployment
+
+import (
+	"reflect"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateDeployment generates Deployment object as per the `Conf`
+// struct passed. If the generated Pod template does not have any
+// labels then the MatchLabels of the generated selector are used as
+// labels for the Pod template, since those are required to match
+// anyway.
+func GenerateDeployment(c Conf) (d *appsv1.Deployment, err error) {
+	var om *metav1.ObjectMeta
+	var template corev1.PodTemplateSpec
+	var replicas *int32
+	var strategy appsv1.DeploymentStrategy
+	var selector *metav1.LabelSelector
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenPodTemplateSpecFunc != nil {
+		var t *corev1.PodTemplateSpec
+		t, err = c.GenPodTemplateSpecFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate pod template")
+		}
+		if t != nil {
+			template = *t
+		}
+	}
+
+	if c.GenContainersFunc != nil {
+		template.Spec.Containers, err = c.GenContainersFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate containers")
+		}
+	}
+
+	if c.GenReplicasFunc != nil {
+		replicas, err = c.GenReplicasFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate replicas")
+		}
+	}
+
+	if c.GenStrategyFunc != nil {
+		strategy, err = c.GenStrategyFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate deployment strategy")
+		}
+	}
+
+	if c.GenSelectorFunc != nil {
+		selector, err = c.GenSelectorFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate selector")
+		}
+	}
+
+	if selector != nil && template.Labels == nil && selector.MatchLabels != nil {
+		template.Labels = make(map[string]string, len(selector.MatchLabels))
+		for key, value := range selector.MatchLabels {
+			template.Labels[key] = value
+		}
+	}
+
+	d = &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: *om,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: replicas,
+			Selector: selector,
+			Template: template,
+			Strategy: strategy,
+		},
+	}
+
+	return d, nil
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for Deployment object. It
+// compares the two Deployments being passed and update the first one
+// if required. API Server fills in a lot of defaults in the Pod
+// template and the strategy, so the comparison only considers the
+// fields set in the generated Deployment. Others are left to
+// whatever is in the cluster. This means that unsetting a field in
+// the generated Deployment does not remove it from the cluster. The
+// replicas are only compared if set, so that Deployments scaled by
+// other controllers are not scaled back. Selector is immutable in
+// apps/v1 and is never updated.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	od, ok := original.(*appsv1.Deployment)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	nd, ok := new.(*appsv1.Deployment)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	update := false
+
+	if nd.Spec.Replicas != nil && !reflect.DeepEqual(od.Spec.Replicas, nd.Spec.Replicas) {
+		od.Spec.Replicas = nd.Spec.Replicas
+		update = true
+	}
+
+	if !equality.Semantic.DeepDerivative(nd.Spec.Strategy, od.Spec.Strategy) {
+		od.Spec.Strategy = nd.Spec.Strategy
+		update = true
+	}
+
+	if !operation.DeepDerivative(nd.Spec.Template, od.Spec.Template) {
+		od.Spec.Template = nd.Spec.Template
+		update = true
+	}
+
+	return update, nil
+}
+
+// Create generates the Deployment as per the `Conf` struct passed and
+// creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var d *appsv1.Deployment
+	var err error
+	if c.GenDeploymentFunc != nil {
+		d, err = c.GenDeploymentFunc(c)
+	} else {
+		d, err = GenerateDeployment(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate deployment")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          d,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create deployment")
+	}
+
+	return result, nil
+}
+
+// Update generates the Deployment as per the `Conf` struct passed and
+// compares it with the in-cluster version. If required, it updates
+// the in-cluster Deployment with the changes. For comparing the
+// Deployments, it uses `MaybeUpdate` function by default but can also
+// use `MaybeUpdateFunc` from `Conf` if passed.
+func Update(c Conf) (reconcile.Result, error) {
+	var d *appsv1.Deployment
+	var err error
+	if c.GenDeploymentFunc != nil {
+		d, err = c.GenDeploymentFunc(c)
+	} else {
+		d, err = GenerateDeployment(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate deployment")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          d,
+		ExistingObject:  &appsv1.Deployment{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update deployment")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update`
+// functions. It creates the Deployment object if it is not already in
+// the cluster and updates the Deployment if one exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var d *appsv1.Deployment
+	var err error
+	if c.GenDeploymentFunc != nil {
+		d, err = c.GenDeploymentFunc(c)
+	} else {
+		d, err = GenerateDeployment(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate deployment")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          d,
+		ExistingObject:  &appsv1.Deployment{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update deployment")
+	}
+
+	return result, nil
+}
+
+// Delete generates the ObjectMeta for Deployment as per the `Conf`
+// struct passed and deletes it from the cluster
+func Delete(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for deployment")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &appsv1.Deployment{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete deployment")
+	}
+
+	return result, nil
+}
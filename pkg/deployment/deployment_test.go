@@ -0,0 +1,449 @@
+package deployment_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/deployment"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+
+	d := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-existing-deployment", Namespace: "test"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(1),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v1"}},
+				},
+			},
+		},
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{d}...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+func TestGenerateDeployment(t *testing.T) {
+	t.Run("generate empty deployment", func(t *testing.T) {
+		expected := &appsv1.Deployment{TypeMeta: metav1.TypeMeta{
+			Kind:       "Deployment",
+			APIVersion: "apps/v1",
+		}}
+
+		result, err := deployment.GenerateDeployment(deployment.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := deployment.GenerateDeployment(deployment.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate pod template", func(t *testing.T) {
+		result, err := deployment.GenerateDeployment(deployment.Conf{
+			GenPodTemplateSpecFunc: func(interfaces.Object) (*corev1.PodTemplateSpec, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate containers", func(t *testing.T) {
+		result, err := deployment.GenerateDeployment(deployment.Conf{
+			GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate replicas", func(t *testing.T) {
+		result, err := deployment.GenerateDeployment(deployment.Conf{
+			GenReplicasFunc: func(interfaces.Object) (*int32, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate strategy", func(t *testing.T) {
+		result, err := deployment.GenerateDeployment(deployment.Conf{
+			GenStrategyFunc: func(interfaces.Object) (appsv1.DeploymentStrategy, error) {
+				return appsv1.DeploymentStrategy{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate selector", func(t *testing.T) {
+		result, err := deployment.GenerateDeployment(deployment.Conf{
+			GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate deployment with containers, replicas, strategy and selector", func(t *testing.T) {
+		expected := &appsv1.Deployment{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Deployment",
+				APIVersion: "apps/v1",
+			},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: int32Ptr(3),
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec: corev1.PodSpec{
+						ServiceAccountName: "test",
+						Containers:         []corev1.Container{{Name: "test", Image: "test:v1"}},
+					},
+				},
+			},
+		}
+
+		result, err := deployment.GenerateDeployment(deployment.Conf{
+			GenPodTemplateSpecFunc: func(interfaces.Object) (*corev1.PodTemplateSpec, error) {
+				return &corev1.PodTemplateSpec{Spec: corev1.PodSpec{ServiceAccountName: "test"}}, nil
+			},
+			GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) {
+				return []corev1.Container{{Name: "test", Image: "test:v1"}}, nil
+			},
+			GenReplicasFunc: func(interfaces.Object) (*int32, error) { return int32Ptr(3), nil },
+			GenStrategyFunc: func(interfaces.Object) (appsv1.DeploymentStrategy, error) {
+				return appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType}, nil
+			},
+			GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+				return &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+	t.Run("pod template labels are not overridden by selector", func(t *testing.T) {
+		result, err := deployment.GenerateDeployment(deployment.Conf{
+			GenPodTemplateSpecFunc: func(interfaces.Object) (*corev1.PodTemplateSpec, error) {
+				return &corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test", "tier": "web"}}}, nil
+			},
+			GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+				return &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"app": "test", "tier": "web"}, result.Spec.Template.Labels)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := deployment.MaybeUpdate(&mocks.MockObject{}, &appsv1.Deployment{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := deployment.MaybeUpdate(&appsv1.Deployment{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := deployment.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare deployments", func(t *testing.T) {
+		t.Run("empty deployments", func(t *testing.T) {
+			result, err := deployment.MaybeUpdate(&appsv1.Deployment{}, &appsv1.Deployment{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("defaulted fields are ignored", func(t *testing.T) {
+			existingDeployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{
+				Replicas: int32Ptr(1),
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyAlways,
+					DNSPolicy:     corev1.DNSClusterFirst,
+					Containers: []corev1.Container{{
+						Name:                     "test",
+						Image:                    "test:v1",
+						ImagePullPolicy:          corev1.PullIfNotPresent,
+						TerminationMessagePath:   corev1.TerminationMessagePathDefault,
+						TerminationMessagePolicy: corev1.TerminationMessageReadFile,
+					}},
+				}},
+			}}
+			newDeployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v1"}},
+				}},
+			}}
+
+			result, err := deployment.MaybeUpdate(existingDeployment, newDeployment)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different replicas", func(t *testing.T) {
+			existingDeployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: int32Ptr(1)}}
+			newDeployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{Replicas: int32Ptr(3)}}
+
+			result, err := deployment.MaybeUpdate(existingDeployment, newDeployment)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingDeployment, newDeployment)
+		})
+		t.Run("different strategy", func(t *testing.T) {
+			existingDeployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType},
+			}}
+			newDeployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{
+				Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			}}
+
+			result, err := deployment.MaybeUpdate(existingDeployment, newDeployment)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingDeployment, newDeployment)
+		})
+		t.Run("different container image", func(t *testing.T) {
+			existingDeployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v1"}},
+				}},
+			}}
+			newDeployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v2"}},
+				}},
+			}}
+
+			result, err := deployment.MaybeUpdate(existingDeployment, newDeployment)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingDeployment, newDeployment)
+		})
+		t.Run("removed container and environment variable", func(t *testing.T) {
+			existingDeployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "test", Image: "test:v1", Env: []corev1.EnvVar{{Name: "FOO", Value: "foo"}, {Name: "BAR", Value: "bar"}}},
+						{Name: "sidecar", Image: "sidecar:v1"},
+					},
+				}},
+			}}
+			newDeployment := &appsv1.Deployment{Spec: appsv1.DeploymentSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v1", Env: []corev1.EnvVar{{Name: "FOO", Value: "foo"}}}},
+				}},
+			}}
+
+			result, err := deployment.MaybeUpdate(existingDeployment, newDeployment)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingDeployment, newDeployment)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := deployment.Create(deployment.Conf{GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := deployment.Create(deployment.Conf{GenDeploymentFunc: func(deployment.Conf) (*appsv1.Deployment, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := deployment.Create(deployment.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create deployment", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := deployment.Create(deployment.Conf{
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := deployment.Update(deployment.Conf{GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := deployment.Update(deployment.Conf{GenDeploymentFunc: func(deployment.Conf) (*appsv1.Deployment, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := deployment.Update(deployment.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("custom maybeupdate function", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := deployment.Update(deployment.Conf{
+			Name:            "test-existing-deployment",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			MaybeUpdateFunc: func(interfaces.Object, interfaces.Object) (bool, error) { return true, nil },
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("update deployment", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := deployment.Update(deployment.Conf{
+			Name:      "test-existing-deployment",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+			GenReplicasFunc: func(interfaces.Object) (*int32, error) {
+				return int32Ptr(3), nil
+			},
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := deployment.CreateOrUpdate(deployment.Conf{GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := deployment.CreateOrUpdate(deployment.Conf{GenDeploymentFunc: func(deployment.Conf) (*appsv1.Deployment, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := deployment.CreateOrUpdate(deployment.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := deployment.CreateOrUpdate(deployment.Conf{
+			Name:      "test-existing-deployment",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update deployment", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := deployment.CreateOrUpdate(deployment.Conf{
+			Name:      "test-existing-deployment",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := deployment.Delete(deployment.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to delete", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := deployment.Delete(deployment.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("delete deployment", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := deployment.Delete(deployment.Conf{
+			Name:      "test-existing-deployment",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
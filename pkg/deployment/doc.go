@@ -0,0 +1,3 @@
+// Package deployment provides functions for manipulating Deployment
+// object in Kubernetes cluster.
+package deployment
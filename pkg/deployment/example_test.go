@@ -0,0 +1,48 @@
+package deployment_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/deployment"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	result, err := deployment.CreateOrUpdate(deployment.Conf{
+		// Instance is the pointer to owner object under which
+		// Deployment is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the Deployment object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated Deployment. There are
+		// several options defines in deployment.Conf which can be
+		// used to manipulate ObjectMeta of the generated object.
+		Name: "deploy-test",
+		// GenSelectorFunc is the function that generates the label
+		// selector for the Deployment. If the Pod template does not
+		// have labels, MatchLabels from the selector are used.
+		GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, nil
+		},
+		// GenContainersFunc is the function that generates the
+		// containers for the Pod template. Check deployment.Conf
+		// struct for other such funtions.
+		GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) {
+			return []corev1.Container{{Name: "nginx", Image: "nginx:1.17"}}, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
@@ -0,0 +1,91 @@
+package deployment
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GenDeploymentFunc defines a function which generates Deployment
+type GenDeploymentFunc func(Conf) (*appsv1.Deployment, error)
+
+// GenPodTemplateSpecFunc defines a function which generates
+// PodTemplateSpec for the Deployment
+type GenPodTemplateSpecFunc func(interfaces.Object) (*corev1.PodTemplateSpec, error)
+
+// GenContainersFunc defines a function which generates slice of
+// Container for the Pod template of the Deployment
+type GenContainersFunc func(interfaces.Object) ([]corev1.Container, error)
+
+// GenReplicasFunc defines a function which generates number of
+// replicas for the Deployment
+type GenReplicasFunc func(interfaces.Object) (*int32, error)
+
+// GenStrategyFunc defines a function which generates the strategy
+// used to replace old Pods by new ones
+type GenStrategyFunc func(interfaces.Object) (appsv1.DeploymentStrategy, error)
+
+// GenSelectorFunc defines a function which generates label selector
+// for the Deployment
+type GenSelectorFunc func(interfaces.Object) (*metav1.LabelSelector, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on Deployment objects.
+type Conf struct {
+	// Instance is the Owner object which manages the Deployment
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the Deployment
+	Name string
+	// Namespace of the Deployment
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on Deployment before creating it in cluster
+	OwnerReference bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for Deployment update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the Deployment
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the Deployment
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the Deployment
+	operation.AfterDeleteFunc
+	// GenDeploymentFunc defines a function to generate the
+	// Deployment object. The package comes with default deployment
+	// generator function which is used by operation functions. By
+	// specifying this field, user can override the default function
+	// with a custom one.
+	GenDeploymentFunc
+	// GenPodTemplateSpecFunc defines a function to generate the Pod
+	// template for the Deployment
+	GenPodTemplateSpecFunc
+	// GenContainersFunc defines a function to generate containers
+	// for the Pod template. If specified, the generated containers
+	// replace the ones in the generated Pod template.
+	GenContainersFunc
+	// GenReplicasFunc defines a function to generate number of
+	// replicas for the Deployment
+	GenReplicasFunc
+	// GenStrategyFunc defines a function to generate the deployment
+	// strategy for the Deployment
+	GenStrategyFunc
+	// GenSelectorFunc defines a function to generate label selector
+	// for the Deployment
+	GenSelectorFunc
+}
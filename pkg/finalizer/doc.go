@@ -0,0 +1,3 @@
+// Package finalizer provides functions for managing the finalizers of
+// the owner object and running the cleanup logic when it is deleted.
+package finalizer
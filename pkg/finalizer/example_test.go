@@ -0,0 +1,46 @@
+package finalizer_test
+
+import (
+	"context"
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/finalizer"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/service"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleHandleDeletion() {
+	deleted, result, err := finalizer.HandleDeletion(finalizer.Conf{
+		// Instance is the pointer to owner object whose finalizer is
+		// managed.
+		Instance: ownerObject,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Finalizer is the name of the finalizer added to the owner
+		// object.
+		Finalizer: "example.com/cleanup",
+		// CleanupFuncs are called in order when the owner object is
+		// being deleted. The finalizer is removed once all of them
+		// succeed without asking for requeue.
+		CleanupFuncs: []finalizer.CleanupFunc{
+			func(ctx context.Context, i interfaces.Object, r interfaces.Reconcile) (reconcile.Result, error) {
+				return service.DeleteWithContext(ctx, service.Conf{
+					Instance:  i,
+					Reconcile: r,
+					Name:      "service-test",
+					Namespace: i.GetNamespace(),
+				})
+			},
+		},
+	})
+	if deleted || err != nil {
+		log.Fatal(result, err)
+	}
+}
@@ -0,0 +1,148 @@
+package finalizer
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Has reports if the object has the finalizer.
+func Has(o interfaces.Object, finalizer string) bool {
+	for _, f := range o.GetFinalizers() {
+		if f == finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// Add adds the finalizer from `Conf` struct passed to the Instance and
+// patches it in the cluster. Nothing is sent if the Instance already
+// has the finalizer.
+func Add(c Conf) error {
+	return AddWithContext(context.Background(), c)
+}
+
+// AddWithContext is same as Add but uses the context passed for the
+// calls to API Server.
+func AddWithContext(ctx context.Context, c Conf) error {
+	if Has(c.Instance, c.Finalizer) {
+		return nil
+	}
+
+	err := patch(ctx, c, append(c.Instance.GetFinalizers(), c.Finalizer))
+	if err != nil {
+		return errors.Wrap(err, "failed to add finalizer")
+	}
+
+	return nil
+}
+
+// Remove removes the finalizer from `Conf` struct passed from the
+// Instance and patches it in the cluster. Nothing is sent if the
+// Instance does not have the finalizer.
+func Remove(c Conf) error {
+	return RemoveWithContext(context.Background(), c)
+}
+
+// RemoveWithContext is same as Remove but uses the context passed for
+// the calls to API Server.
+func RemoveWithContext(ctx context.Context, c Conf) error {
+	if !Has(c.Instance, c.Finalizer) {
+		return nil
+	}
+
+	finalizers := []string{}
+	for _, f := range c.Instance.GetFinalizers() {
+		if f != c.Finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+
+	err := patch(ctx, c, finalizers)
+	if err != nil {
+		return errors.Wrap(err, "failed to remove finalizer")
+	}
+
+	return nil
+}
+
+// HandleDeletion implements the finalizer flow for the Instance. If
+// the Instance is not being deleted, it adds the finalizer and returns
+// false so that the reconcile can go on. If the Instance is being
+// deleted, it calls the CleanupFuncs in order and removes the
+// finalizer once they are done, which lets Kubernetes delete the
+// Instance. It returns true in that case and the reconcile is
+// supposed to return the result and error without doing anything
+// else. The finalizer is kept if a CleanupFunc fails or asks for
+// requeue, and the CleanupFuncs are called again in the next
+// reconcile.
+//
+//	deleted, result, err := finalizer.HandleDeletion(c)
+//	if deleted || err != nil {
+//	        return result, err
+//	}
+func HandleDeletion(c Conf) (bool, reconcile.Result, error) {
+	return HandleDeletionWithContext(context.Background(), c)
+}
+
+// HandleDeletionWithContext is same as HandleDeletion but uses the
+// context passed for the calls to API Server and the CleanupFuncs.
+func HandleDeletionWithContext(ctx context.Context, c Conf) (bool, reconcile.Result, error) {
+	if c.Instance.GetDeletionTimestamp() == nil {
+		return false, reconcile.Result{}, AddWithContext(ctx, c)
+	}
+
+	if !Has(c.Instance, c.Finalizer) {
+		return true, reconcile.Result{}, nil
+	}
+
+	var result reconcile.Result
+	for _, cleanup := range c.CleanupFuncs {
+		r, err := cleanup(ctx, c.Instance, c.Reconcile)
+		result = operation.MergeResults(result, r)
+		if err != nil {
+			return true, result, errors.Wrap(err, "failed to clean up")
+		}
+	}
+
+	if result.Requeue || result.RequeueAfter > 0 {
+		return true, result, nil
+	}
+
+	return true, result, RemoveWithContext(ctx, c)
+}
+
+// patch sets the finalizers of the Instance and sends them to API
+// Server as a merge patch. The patch replaces the whole list, so it
+// carries the resourceVersion of the Instance to fail with conflict
+// if someone else changed the finalizers in the meantime.
+func patch(ctx context.Context, c Conf, finalizers []string) error {
+	metadata := map[string]interface{}{"finalizers": finalizers}
+	if rv := c.Instance.GetResourceVersion(); rv != "" {
+		metadata["resourceVersion"] = rv
+	}
+
+	data, err := json.Marshal(map[string]interface{}{"metadata": metadata})
+	if err != nil {
+		return errors.Wrap(err, "failed to encode the patch")
+	}
+
+	ctx, cancel := operation.CallContext(ctx, c.Timeout)
+	defer cancel()
+
+	err = c.Reconcile.GetClient().Patch(ctx, c.Instance, client.ConstantPatch(types.MergePatchType, data))
+	if err != nil {
+		return err
+	}
+
+	c.Instance.SetFinalizers(finalizers)
+	return nil
+}
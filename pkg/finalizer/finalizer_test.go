@@ -0,0 +1,200 @@
+package finalizer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/finalizer"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const testFinalizer = "test.example.com/cleanup"
+
+func mockSetup(ctrl *gomock.Controller, finalizers []string, deleted bool) (i *corev1.ConfigMap, r *mocks.MockReconcile) {
+	i = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Finalizers: finalizers},
+	}
+	if deleted {
+		now := metav1.Now()
+		i.SetDeletionTimestamp(&now)
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{i.DeepCopy()}...)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(scheme.Scheme).AnyTimes()
+
+	return i, r
+}
+
+func getFinalizers(t *testing.T, r interfaces.Reconcile) []string {
+	i := &corev1.ConfigMap{}
+	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test", Namespace: "test"}, i)
+	assert.NoError(t, err)
+	return i.GetFinalizers()
+}
+
+func TestHas(t *testing.T) {
+	assert.False(t, finalizer.Has(&corev1.ConfigMap{}, testFinalizer))
+	assert.False(t, finalizer.Has(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Finalizers: []string{"other"}}}, testFinalizer))
+	assert.True(t, finalizer.Has(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Finalizers: []string{"other", testFinalizer}}}, testFinalizer))
+}
+
+func TestAdd(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("add finalizer", func(t *testing.T) {
+		i, r := mockSetup(controller, []string{"other"}, false)
+
+		err := finalizer.Add(finalizer.Conf{Instance: i, Reconcile: r, Finalizer: testFinalizer})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"other", testFinalizer}, i.GetFinalizers())
+		assert.Equal(t, []string{"other", testFinalizer}, getFinalizers(t, r))
+	})
+	t.Run("add existing finalizer", func(t *testing.T) {
+		i, r := mockSetup(controller, []string{testFinalizer}, false)
+
+		err := finalizer.Add(finalizer.Conf{Instance: i, Reconcile: r, Finalizer: testFinalizer})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{testFinalizer}, getFinalizers(t, r))
+	})
+	t.Run("add finalizer to instance which does not exist", func(t *testing.T) {
+		_, r := mockSetup(controller, nil, false)
+
+		err := finalizer.Add(finalizer.Conf{
+			Instance:  &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "test"}},
+			Reconcile: r,
+			Finalizer: testFinalizer,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestRemove(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("remove finalizer", func(t *testing.T) {
+		i, r := mockSetup(controller, []string{"other", testFinalizer}, false)
+
+		err := finalizer.Remove(finalizer.Conf{Instance: i, Reconcile: r, Finalizer: testFinalizer})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"other"}, getFinalizers(t, r))
+	})
+	t.Run("remove last finalizer", func(t *testing.T) {
+		i, r := mockSetup(controller, []string{testFinalizer}, false)
+
+		err := finalizer.Remove(finalizer.Conf{Instance: i, Reconcile: r, Finalizer: testFinalizer})
+		assert.NoError(t, err)
+		assert.Empty(t, getFinalizers(t, r))
+	})
+}
+
+func TestHandleDeletion(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	var calls []string
+	cleanup := func(name string, result reconcile.Result, err error) finalizer.CleanupFunc {
+		return func(context.Context, interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+			calls = append(calls, name)
+			return result, err
+		}
+	}
+
+	t.Run("instance is not being deleted", func(t *testing.T) {
+		i, r := mockSetup(controller, nil, false)
+		calls = nil
+
+		deleted, _, err := finalizer.HandleDeletion(finalizer.Conf{
+			Instance:     i,
+			Reconcile:    r,
+			Finalizer:    testFinalizer,
+			CleanupFuncs: []finalizer.CleanupFunc{cleanup("first", reconcile.Result{}, nil)},
+		})
+		assert.NoError(t, err)
+		assert.False(t, deleted)
+		assert.Empty(t, calls)
+		assert.Equal(t, []string{testFinalizer}, getFinalizers(t, r))
+	})
+	t.Run("instance is being deleted", func(t *testing.T) {
+		i, r := mockSetup(controller, []string{testFinalizer, "other"}, true)
+		calls = nil
+
+		deleted, _, err := finalizer.HandleDeletion(finalizer.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Finalizer: testFinalizer,
+			CleanupFuncs: []finalizer.CleanupFunc{
+				cleanup("first", reconcile.Result{}, nil),
+				cleanup("second", reconcile.Result{}, nil),
+			},
+		})
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.Equal(t, []string{"first", "second"}, calls)
+		assert.Equal(t, []string{"other"}, getFinalizers(t, r))
+	})
+	t.Run("cleanup is in progress", func(t *testing.T) {
+		i, r := mockSetup(controller, []string{testFinalizer}, true)
+		calls = nil
+
+		deleted, result, err := finalizer.HandleDeletion(finalizer.Conf{
+			Instance:     i,
+			Reconcile:    r,
+			Finalizer:    testFinalizer,
+			CleanupFuncs: []finalizer.CleanupFunc{cleanup("first", reconcile.Result{RequeueAfter: time.Second}, nil)},
+		})
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.Equal(t, reconcile.Result{RequeueAfter: time.Second}, result)
+		assert.Equal(t, []string{testFinalizer}, getFinalizers(t, r))
+	})
+	t.Run("cleanup fails", func(t *testing.T) {
+		i, r := mockSetup(controller, []string{testFinalizer}, true)
+		calls = nil
+
+		deleted, _, err := finalizer.HandleDeletion(finalizer.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Finalizer: testFinalizer,
+			CleanupFuncs: []finalizer.CleanupFunc{
+				cleanup("first", reconcile.Result{}, errors.New("test error")),
+				cleanup("second", reconcile.Result{}, nil),
+			},
+		})
+		assert.Error(t, err)
+		assert.True(t, deleted)
+		assert.Equal(t, []string{"first"}, calls)
+		assert.Equal(t, []string{testFinalizer}, getFinalizers(t, r))
+	})
+	t.Run("instance without finalizer is being deleted", func(t *testing.T) {
+		i, r := mockSetup(controller, []string{"other"}, true)
+		calls = nil
+
+		deleted, _, err := finalizer.HandleDeletion(finalizer.Conf{
+			Instance:     i,
+			Reconcile:    r,
+			Finalizer:    testFinalizer,
+			CleanupFuncs: []finalizer.CleanupFunc{cleanup("first", reconcile.Result{}, nil)},
+		})
+		assert.NoError(t, err)
+		assert.True(t, deleted)
+		assert.Empty(t, calls)
+	})
+}
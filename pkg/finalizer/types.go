@@ -0,0 +1,34 @@
+package finalizer
+
+import (
+	"context"
+	"time"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// CleanupFunc defines a function which cleans up the resources of the
+// owner object being deleted, like deleting a Service with
+// service.Delete. The cleanup is considered in progress, and the
+// finalizer is kept, as long as the function asks for requeue.
+type CleanupFunc func(context.Context, interfaces.Object, interfaces.Reconcile) (reconcile.Result, error)
+
+// Conf is used to pass parameters to functions in this package to
+// manage the finalizer of the owner object.
+type Conf struct {
+	// Instance is the Owner object whose finalizer is managed
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Finalizer is the name of the finalizer, like
+	// "example.com/cleanup"
+	Finalizer string
+	// CleanupFuncs are called in order by HandleDeletion before
+	// removing the finalizer
+	CleanupFuncs []CleanupFunc
+	// Timeout limits the duration of every call made to the API
+	// Server while patching the Instance
+	Timeout time.Duration
+}
@@ -0,0 +1,3 @@
+// Package hpa provides functions for manipulating
+// HorizontalPodAutoscaler object in Kubernetes cluster.
+package hpa
@@ -0,0 +1,58 @@
+package hpa_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/hpa"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	result, err := hpa.CreateOrUpdate(hpa.Conf{
+		// Instance is the pointer to owner object under which
+		// HorizontalPodAutoscaler is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the HorizontalPodAutoscaler object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated HorizontalPodAutoscaler.
+		Name: "hpa-test",
+		// GenScaleTargetRefFunc is the function that generates the
+		// reference to the scaled workload. ScaleTargetRef targets
+		// the workload by kind and name.
+		GenScaleTargetRefFunc: hpa.ScaleTargetRef("Deployment", "deployment-test"),
+		// GenMaxReplicasFunc is the function that generates the upper
+		// limit for the number of replicas.
+		GenMaxReplicasFunc: func(interfaces.Object) (int32, error) {
+			return 5, nil
+		},
+		// GenMetricsFunc is the function that generates the metrics
+		// used to calculate the desired number of replicas.
+		GenMetricsFunc: func(interfaces.Object) ([]autoscalingv2beta2.MetricSpec, error) {
+			utilization := int32(80)
+			return []autoscalingv2beta2.MetricSpec{{
+				Type: autoscalingv2beta2.ResourceMetricSourceType,
+				Resource: &autoscalingv2beta2.ResourceMetricSource{
+					Name: corev1.ResourceCPU,
+					Target: autoscalingv2beta2.MetricTarget{
+						Type:               autoscalingv2beta2.UtilizationMetricType,
+						AverageUtilization: &utilization,
+					},
+				},
+			}}, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
@@ -0,0 +1,277 @@
+package hpa
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateHorizontalPodAutoscaler generates HorizontalPodAutoscaler
+// object as per the `Conf` struct passed.
+func GenerateHorizontalPodAutoscaler(c Conf) (hpa *autoscalingv2beta2.HorizontalPodAutoscaler, err error) {
+	var om *metav1.ObjectMeta
+	var scaleTargetRef autoscalingv2beta2.CrossVersionObjectReference
+	var minReplicas *int32
+	var maxReplicas int32
+	var metrics []autoscalingv2beta2.MetricSpec
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenScaleTargetRefFunc != nil {
+		scaleTargetRef, err = c.GenScaleTargetRefFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate scale target reference")
+		}
+	}
+
+	if c.GenMinReplicasFunc != nil {
+		minReplicas, err = c.GenMinReplicasFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate min replicas")
+		}
+	}
+
+	if c.GenMaxReplicasFunc != nil {
+		maxReplicas, err = c.GenMaxReplicasFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate max replicas")
+		}
+	}
+
+	if c.GenMetricsFunc != nil {
+		metrics, err = c.GenMetricsFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate metrics")
+		}
+	}
+
+	hpa = &autoscalingv2beta2.HorizontalPodAutoscaler{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v2beta2",
+		},
+		ObjectMeta: *om,
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: scaleTargetRef,
+			MinReplicas:    minReplicas,
+			MaxReplicas:    maxReplicas,
+			Metrics:        metrics,
+		},
+	}
+
+	return hpa, nil
+}
+
+// ScaleTargetRef returns a GenScaleTargetRefFunc which targets the
+// workload of given kind and name. Kind can be one of Deployment,
+// StatefulSet and ReplicaSet.
+func ScaleTargetRef(kind, name string) GenScaleTargetRefFunc {
+	return func(interfaces.Object) (autoscalingv2beta2.CrossVersionObjectReference, error) {
+		switch kind {
+		case "Deployment", "StatefulSet", "ReplicaSet":
+			return autoscalingv2beta2.CrossVersionObjectReference{
+				Kind:       kind,
+				Name:       name,
+				APIVersion: "apps/v1",
+			}, nil
+		default:
+			return autoscalingv2beta2.CrossVersionObjectReference{}, errors.Errorf("unsupported scale target kind %q", kind)
+		}
+	}
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for HorizontalPodAutoscaler
+// object. It compares the two HorizontalPodAutoscalers being passed
+// and update the first one if required. Only the spec is compared,
+// the status is maintained by the controller. Minimum replicas and
+// metrics are defaulted by API Server so they are only compared if
+// set.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	ohpa, ok := original.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	nhpa, ok := new.(*autoscalingv2beta2.HorizontalPodAutoscaler)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	update := false
+
+	if !equality.Semantic.DeepEqual(ohpa.Spec.ScaleTargetRef, nhpa.Spec.ScaleTargetRef) {
+		ohpa.Spec.ScaleTargetRef = nhpa.Spec.ScaleTargetRef
+		update = true
+	}
+
+	if ohpa.Spec.MaxReplicas != nhpa.Spec.MaxReplicas {
+		ohpa.Spec.MaxReplicas = nhpa.Spec.MaxReplicas
+		update = true
+	}
+
+	if nhpa.Spec.MinReplicas != nil && !equality.Semantic.DeepEqual(ohpa.Spec.MinReplicas, nhpa.Spec.MinReplicas) {
+		ohpa.Spec.MinReplicas = nhpa.Spec.MinReplicas
+		update = true
+	}
+
+	if nhpa.Spec.Metrics != nil && (len(ohpa.Spec.Metrics) != len(nhpa.Spec.Metrics) ||
+		!equality.Semantic.DeepDerivative(nhpa.Spec.Metrics, ohpa.Spec.Metrics)) {
+		ohpa.Spec.Metrics = nhpa.Spec.Metrics
+		update = true
+	}
+
+	return update, nil
+}
+
+// Create generates the HorizontalPodAutoscaler as per the `Conf`
+// struct passed and creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var hpa *autoscalingv2beta2.HorizontalPodAutoscaler
+	var err error
+	if c.GenHorizontalPodAutoscalerFunc != nil {
+		hpa, err = c.GenHorizontalPodAutoscalerFunc(c)
+	} else {
+		hpa, err = GenerateHorizontalPodAutoscaler(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate horizontalpodautoscaler")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          hpa,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create horizontalpodautoscaler")
+	}
+
+	return result, nil
+}
+
+// Update generates the HorizontalPodAutoscaler as per the `Conf`
+// struct passed and compares it with the in-cluster version. If
+// required, it updates the in-cluster HorizontalPodAutoscaler with
+// the changes. For comparing the HorizontalPodAutoscalers, it uses
+// `MaybeUpdate` function by default but can also use
+// `MaybeUpdateFunc` from `Conf` if passed.
+func Update(c Conf) (reconcile.Result, error) {
+	var hpa *autoscalingv2beta2.HorizontalPodAutoscaler
+	var err error
+	if c.GenHorizontalPodAutoscalerFunc != nil {
+		hpa, err = c.GenHorizontalPodAutoscalerFunc(c)
+	} else {
+		hpa, err = GenerateHorizontalPodAutoscaler(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate horizontalpodautoscaler")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          hpa,
+		ExistingObject:  &autoscalingv2beta2.HorizontalPodAutoscaler{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update horizontalpodautoscaler")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update` functions.
+// It creates the HorizontalPodAutoscaler object if it is not already
+// in the cluster and updates the HorizontalPodAutoscaler if one
+// exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var hpa *autoscalingv2beta2.HorizontalPodAutoscaler
+	var err error
+	if c.GenHorizontalPodAutoscalerFunc != nil {
+		hpa, err = c.GenHorizontalPodAutoscalerFunc(c)
+	} else {
+		hpa, err = GenerateHorizontalPodAutoscaler(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate horizontalpodautoscaler")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          hpa,
+		ExistingObject:  &autoscalingv2beta2.HorizontalPodAutoscaler{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update horizontalpodautoscaler")
+	}
+
+	return result, nil
+}
+
+// Delete generates the ObjectMeta for HorizontalPodAutoscaler as per
+// the `Conf` struct passed and deletes it from the cluster
+func Delete(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for horizontalpodautoscaler")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &autoscalingv2beta2.HorizontalPodAutoscaler{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete horizontalpodautoscaler")
+	}
+
+	return result, nil
+}
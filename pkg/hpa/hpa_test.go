@@ -0,0 +1,369 @@
+package hpa_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/hpa"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+
+	h := &autoscalingv2beta2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-existing-hpa", Namespace: "test"},
+		Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{Kind: "Deployment", Name: "test", APIVersion: "apps/v1"},
+			MinReplicas:    int32Ptr(1),
+			MaxReplicas:    3,
+		},
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{h}...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+func TestGenerateHorizontalPodAutoscaler(t *testing.T) {
+	t.Run("generate empty horizontalpodautoscaler", func(t *testing.T) {
+		expected := &autoscalingv2beta2.HorizontalPodAutoscaler{TypeMeta: metav1.TypeMeta{
+			Kind:       "HorizontalPodAutoscaler",
+			APIVersion: "autoscaling/v2beta2",
+		}}
+
+		result, err := hpa.GenerateHorizontalPodAutoscaler(hpa.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := hpa.GenerateHorizontalPodAutoscaler(hpa.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate scale target reference", func(t *testing.T) {
+		result, err := hpa.GenerateHorizontalPodAutoscaler(hpa.Conf{
+			GenScaleTargetRefFunc: hpa.ScaleTargetRef("Pod", "test"),
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate min replicas", func(t *testing.T) {
+		result, err := hpa.GenerateHorizontalPodAutoscaler(hpa.Conf{
+			GenMinReplicasFunc: func(interfaces.Object) (*int32, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate max replicas", func(t *testing.T) {
+		result, err := hpa.GenerateHorizontalPodAutoscaler(hpa.Conf{
+			GenMaxReplicasFunc: func(interfaces.Object) (int32, error) { return 0, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate metrics", func(t *testing.T) {
+		result, err := hpa.GenerateHorizontalPodAutoscaler(hpa.Conf{
+			GenMetricsFunc: func(interfaces.Object) ([]autoscalingv2beta2.MetricSpec, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate horizontalpodautoscaler", func(t *testing.T) {
+		expected := &autoscalingv2beta2.HorizontalPodAutoscaler{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "HorizontalPodAutoscaler",
+				APIVersion: "autoscaling/v2beta2",
+			},
+			Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+				ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{Kind: "StatefulSet", Name: "test", APIVersion: "apps/v1"},
+				MinReplicas:    int32Ptr(2),
+				MaxReplicas:    5,
+			},
+		}
+
+		result, err := hpa.GenerateHorizontalPodAutoscaler(hpa.Conf{
+			GenScaleTargetRefFunc: hpa.ScaleTargetRef("StatefulSet", "test"),
+			GenMinReplicasFunc:    func(interfaces.Object) (*int32, error) { return int32Ptr(2), nil },
+			GenMaxReplicasFunc:    func(interfaces.Object) (int32, error) { return 5, nil },
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestScaleTargetRef(t *testing.T) {
+	t.Run("supported kinds", func(t *testing.T) {
+		for _, kind := range []string{"Deployment", "StatefulSet", "ReplicaSet"} {
+			result, err := hpa.ScaleTargetRef(kind, "test")(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, autoscalingv2beta2.CrossVersionObjectReference{Kind: kind, Name: "test", APIVersion: "apps/v1"}, result)
+		}
+	})
+	t.Run("unsupported kind", func(t *testing.T) {
+		_, err := hpa.ScaleTargetRef("DaemonSet", "test")(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := hpa.MaybeUpdate(&mocks.MockObject{}, &autoscalingv2beta2.HorizontalPodAutoscaler{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := hpa.MaybeUpdate(&autoscalingv2beta2.HorizontalPodAutoscaler{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := hpa.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare horizontalpodautoscalers", func(t *testing.T) {
+		t.Run("empty horizontalpodautoscalers", func(t *testing.T) {
+			result, err := hpa.MaybeUpdate(&autoscalingv2beta2.HorizontalPodAutoscaler{}, &autoscalingv2beta2.HorizontalPodAutoscaler{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("status and defaults are ignored", func(t *testing.T) {
+			existingHorizontalPodAutoscaler := &autoscalingv2beta2.HorizontalPodAutoscaler{
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+					MinReplicas: int32Ptr(1),
+					MaxReplicas: 3,
+					Metrics: []autoscalingv2beta2.MetricSpec{{
+						Type: autoscalingv2beta2.ResourceMetricSourceType,
+					}},
+				},
+				Status: autoscalingv2beta2.HorizontalPodAutoscalerStatus{CurrentReplicas: 2, DesiredReplicas: 3},
+			}
+			newHorizontalPodAutoscaler := &autoscalingv2beta2.HorizontalPodAutoscaler{
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{MaxReplicas: 3},
+			}
+
+			result, err := hpa.MaybeUpdate(existingHorizontalPodAutoscaler, newHorizontalPodAutoscaler)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different scale target", func(t *testing.T) {
+			existingHorizontalPodAutoscaler := &autoscalingv2beta2.HorizontalPodAutoscaler{
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{Kind: "Deployment", Name: "test"},
+				},
+			}
+			newHorizontalPodAutoscaler := &autoscalingv2beta2.HorizontalPodAutoscaler{
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+					ScaleTargetRef: autoscalingv2beta2.CrossVersionObjectReference{Kind: "StatefulSet", Name: "test"},
+				},
+			}
+
+			result, err := hpa.MaybeUpdate(existingHorizontalPodAutoscaler, newHorizontalPodAutoscaler)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingHorizontalPodAutoscaler, newHorizontalPodAutoscaler)
+		})
+		t.Run("different replicas", func(t *testing.T) {
+			existingHorizontalPodAutoscaler := &autoscalingv2beta2.HorizontalPodAutoscaler{
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{MinReplicas: int32Ptr(1), MaxReplicas: 3},
+			}
+			newHorizontalPodAutoscaler := &autoscalingv2beta2.HorizontalPodAutoscaler{
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{MinReplicas: int32Ptr(2), MaxReplicas: 5},
+			}
+
+			result, err := hpa.MaybeUpdate(existingHorizontalPodAutoscaler, newHorizontalPodAutoscaler)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingHorizontalPodAutoscaler, newHorizontalPodAutoscaler)
+		})
+		t.Run("metric removed", func(t *testing.T) {
+			existingHorizontalPodAutoscaler := &autoscalingv2beta2.HorizontalPodAutoscaler{
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+					Metrics: []autoscalingv2beta2.MetricSpec{
+						{Type: autoscalingv2beta2.ResourceMetricSourceType},
+						{Type: autoscalingv2beta2.PodsMetricSourceType},
+					},
+				},
+			}
+			newHorizontalPodAutoscaler := &autoscalingv2beta2.HorizontalPodAutoscaler{
+				Spec: autoscalingv2beta2.HorizontalPodAutoscalerSpec{
+					Metrics: []autoscalingv2beta2.MetricSpec{{Type: autoscalingv2beta2.ResourceMetricSourceType}},
+				},
+			}
+
+			result, err := hpa.MaybeUpdate(existingHorizontalPodAutoscaler, newHorizontalPodAutoscaler)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingHorizontalPodAutoscaler, newHorizontalPodAutoscaler)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := hpa.Create(hpa.Conf{GenMetricsFunc: func(interfaces.Object) ([]autoscalingv2beta2.MetricSpec, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := hpa.Create(hpa.Conf{GenHorizontalPodAutoscalerFunc: func(hpa.Conf) (*autoscalingv2beta2.HorizontalPodAutoscaler, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := hpa.Create(hpa.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create horizontalpodautoscaler", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := hpa.Create(hpa.Conf{
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := hpa.Update(hpa.Conf{GenHorizontalPodAutoscalerFunc: func(hpa.Conf) (*autoscalingv2beta2.HorizontalPodAutoscaler, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := hpa.Update(hpa.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("update horizontalpodautoscaler", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := hpa.Update(hpa.Conf{
+			Name:      "test-existing-hpa",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+			GenMaxReplicasFunc: func(interfaces.Object) (int32, error) {
+				return 5, nil
+			},
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := hpa.CreateOrUpdate(hpa.Conf{GenHorizontalPodAutoscalerFunc: func(hpa.Conf) (*autoscalingv2beta2.HorizontalPodAutoscaler, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := hpa.CreateOrUpdate(hpa.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update horizontalpodautoscaler", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := hpa.CreateOrUpdate(hpa.Conf{
+			Name:      "test-existing-hpa",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := hpa.Delete(hpa.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to delete", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := hpa.Delete(hpa.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("delete horizontalpodautoscaler", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := hpa.Delete(hpa.Conf{
+			Name:      "test-existing-hpa",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
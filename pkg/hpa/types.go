@@ -0,0 +1,87 @@
+package hpa
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+)
+
+// GenHorizontalPodAutoscalerFunc defines a function which generates
+// HorizontalPodAutoscaler
+type GenHorizontalPodAutoscalerFunc func(Conf) (*autoscalingv2beta2.HorizontalPodAutoscaler, error)
+
+// GenScaleTargetRefFunc defines a function which generates reference
+// to the workload scaled by the HorizontalPodAutoscaler
+type GenScaleTargetRefFunc func(interfaces.Object) (autoscalingv2beta2.CrossVersionObjectReference, error)
+
+// GenMinReplicasFunc defines a function which generates the lower
+// limit for the number of replicas
+type GenMinReplicasFunc func(interfaces.Object) (*int32, error)
+
+// GenMaxReplicasFunc defines a function which generates the upper
+// limit for the number of replicas
+type GenMaxReplicasFunc func(interfaces.Object) (int32, error)
+
+// GenMetricsFunc defines a function which generates metrics used to
+// calculate the desired number of replicas
+type GenMetricsFunc func(interfaces.Object) ([]autoscalingv2beta2.MetricSpec, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on HorizontalPodAutoscaler objects.
+type Conf struct {
+	// Instance is the Owner object which manages the
+	// HorizontalPodAutoscaler
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the HorizontalPodAutoscaler
+	Name string
+	// Namespace of the HorizontalPodAutoscaler
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on HorizontalPodAutoscaler before creating it in cluster
+	OwnerReference bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for HorizontalPodAutoscaler update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the
+	// HorizontalPodAutoscaler
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the
+	// HorizontalPodAutoscaler
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the
+	// HorizontalPodAutoscaler
+	operation.AfterDeleteFunc
+	// GenHorizontalPodAutoscalerFunc defines a function to generate
+	// the HorizontalPodAutoscaler object. The package comes with
+	// default generator function which is used by operation
+	// functions. By specifying this field, user can override the
+	// default function with a custom one.
+	GenHorizontalPodAutoscalerFunc
+	// GenScaleTargetRefFunc defines a function to generate the
+	// reference to the scaled workload. ScaleTargetRef can be used
+	// for the common workload kinds.
+	GenScaleTargetRefFunc
+	// GenMinReplicasFunc defines a function to generate minimum
+	// replicas for the HorizontalPodAutoscaler
+	GenMinReplicasFunc
+	// GenMaxReplicasFunc defines a function to generate maximum
+	// replicas for the HorizontalPodAutoscaler
+	GenMaxReplicasFunc
+	// GenMetricsFunc defines a function to generate metrics for the
+	// HorizontalPodAutoscaler
+	GenMetricsFunc
+}
@@ -0,0 +1,3 @@
+// Package ingress provides functions for manipulating Ingress object
+// in Kubernetes cluster.
+package ingress
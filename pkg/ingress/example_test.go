@@ -0,0 +1,64 @@
+package ingress_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/ingress"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	result, err := ingress.CreateOrUpdate(ingress.Conf{
+		// Instance is the pointer to owner object under which
+		// Ingress is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the Ingress object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated Ingress.
+		Name: "ing-test",
+		// GenIngressClassFunc is the function that generates the
+		// class of the Ingress controller serving the Ingress.
+		GenIngressClassFunc: func(interfaces.Object) (string, error) {
+			return "nginx", nil
+		},
+		// GenRulesFunc is the function that generates the host rules
+		// routing the traffic to the backend Services.
+		GenRulesFunc: func(interfaces.Object) ([]networkingv1beta1.IngressRule, error) {
+			return []networkingv1beta1.IngressRule{{
+				Host: "app.example.com",
+				IngressRuleValue: networkingv1beta1.IngressRuleValue{
+					HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+						Paths: []networkingv1beta1.HTTPIngressPath{{
+							Path: "/",
+							Backend: networkingv1beta1.IngressBackend{
+								ServiceName: "svc-test",
+								ServicePort: intstr.FromInt(80),
+							},
+						}},
+					},
+				},
+			}}, nil
+		},
+		// GenTLSFunc is the function that generates the TLS
+		// configuration. Since no hosts are specified, the hosts of
+		// all the rules are served with the certificate in the
+		// Secret.
+		GenTLSFunc: func(interfaces.Object) ([]networkingv1beta1.IngressTLS, error) {
+			return []networkingv1beta1.IngressTLS{{SecretName: "app-tls"}}, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
@@ -0,0 +1,279 @@
+package ingress
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateIngress generates Ingress object as per the `Conf` struct
+// passed. The generated class is set as ClassAnnotation on the
+// Ingress. If a generated TLS block does not specify any hosts, the
+// hosts of all the generated rules are used, so that the certificate
+// in the TLS Secret is served for every host of the Ingress.
+func GenerateIngress(c Conf) (ing *networkingv1beta1.Ingress, err error) {
+	var om *metav1.ObjectMeta
+	var rules []networkingv1beta1.IngressRule
+	var tls []networkingv1beta1.IngressTLS
+	var class string
+	var backend *networkingv1beta1.IngressBackend
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenRulesFunc != nil {
+		rules, err = c.GenRulesFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate rules")
+		}
+	}
+
+	if c.GenTLSFunc != nil {
+		tls, err = c.GenTLSFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate tls")
+		}
+	}
+
+	if c.GenIngressClassFunc != nil {
+		class, err = c.GenIngressClassFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate ingress class")
+		}
+	}
+
+	if c.GenBackendFunc != nil {
+		backend, err = c.GenBackendFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate backend")
+		}
+	}
+
+	if class != "" {
+		if om.Annotations == nil {
+			om.Annotations = make(map[string]string, 1)
+		}
+		om.Annotations[ClassAnnotation] = class
+	}
+
+	for i := range tls {
+		if len(tls[i].Hosts) != 0 {
+			continue
+		}
+		for _, rule := range rules {
+			if rule.Host != "" {
+				tls[i].Hosts = append(tls[i].Hosts, rule.Host)
+			}
+		}
+	}
+
+	ing = &networkingv1beta1.Ingress{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1beta1",
+		},
+		ObjectMeta: *om,
+		Spec: networkingv1beta1.IngressSpec{
+			Backend: backend,
+			TLS:     tls,
+			Rules:   rules,
+		},
+	}
+
+	return ing, nil
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for Ingress object. It
+// compares the two Ingresses being passed and update the first one if
+// required. Rules, TLS and the default backend are compared along
+// with ClassAnnotation, which is only compared if set in the new
+// Ingress. Status of the Ingress is populated by the Ingress
+// controller and is never compared.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	oing, ok := original.(*networkingv1beta1.Ingress)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	ning, ok := new.(*networkingv1beta1.Ingress)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	update := false
+
+	if class, ok := ning.Annotations[ClassAnnotation]; ok && oing.Annotations[ClassAnnotation] != class {
+		if oing.Annotations == nil {
+			oing.Annotations = make(map[string]string, 1)
+		}
+		oing.Annotations[ClassAnnotation] = class
+		update = true
+	}
+
+	if !equality.Semantic.DeepEqual(oing.Spec.Rules, ning.Spec.Rules) {
+		oing.Spec.Rules = ning.Spec.Rules
+		update = true
+	}
+
+	if !equality.Semantic.DeepEqual(oing.Spec.TLS, ning.Spec.TLS) {
+		oing.Spec.TLS = ning.Spec.TLS
+		update = true
+	}
+
+	if !equality.Semantic.DeepEqual(oing.Spec.Backend, ning.Spec.Backend) {
+		oing.Spec.Backend = ning.Spec.Backend
+		update = true
+	}
+
+	return update, nil
+}
+
+// Create generates the Ingress as per the `Conf` struct passed and
+// creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var ing *networkingv1beta1.Ingress
+	var err error
+	if c.GenIngressFunc != nil {
+		ing, err = c.GenIngressFunc(c)
+	} else {
+		ing, err = GenerateIngress(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate ingress")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          ing,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create ingress")
+	}
+
+	return result, nil
+}
+
+// Update generates the Ingress as per the `Conf` struct passed and
+// compares it with the in-cluster version. If required, it updates
+// the in-cluster Ingress with the changes. For comparing the
+// Ingresses, it uses `MaybeUpdate` function by default but can also
+// use `MaybeUpdateFunc` from `Conf` if passed.
+func Update(c Conf) (reconcile.Result, error) {
+	var ing *networkingv1beta1.Ingress
+	var err error
+	if c.GenIngressFunc != nil {
+		ing, err = c.GenIngressFunc(c)
+	} else {
+		ing, err = GenerateIngress(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate ingress")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          ing,
+		ExistingObject:  &networkingv1beta1.Ingress{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update ingress")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update` functions.
+// It creates the Ingress object if it is not already in the cluster
+// and updates the Ingress if one exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var ing *networkingv1beta1.Ingress
+	var err error
+	if c.GenIngressFunc != nil {
+		ing, err = c.GenIngressFunc(c)
+	} else {
+		ing, err = GenerateIngress(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate ingress")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          ing,
+		ExistingObject:  &networkingv1beta1.Ingress{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update ingress")
+	}
+
+	return result, nil
+}
+
+// Delete generates the ObjectMeta for Ingress as per the `Conf`
+// struct passed and deletes it from the cluster
+func Delete(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for ingress")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &networkingv1beta1.Ingress{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete ingress")
+	}
+
+	return result, nil
+}
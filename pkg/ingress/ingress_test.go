@@ -0,0 +1,400 @@
+package ingress_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/ingress"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func testRule(host string) networkingv1beta1.IngressRule {
+	return networkingv1beta1.IngressRule{
+		Host: host,
+		IngressRuleValue: networkingv1beta1.IngressRuleValue{
+			HTTP: &networkingv1beta1.HTTPIngressRuleValue{
+				Paths: []networkingv1beta1.HTTPIngressPath{{
+					Path: "/",
+					Backend: networkingv1beta1.IngressBackend{
+						ServiceName: "test",
+						ServicePort: intstr.FromInt(80),
+					},
+				}},
+			},
+		},
+	}
+}
+
+func testRules(interfaces.Object) ([]networkingv1beta1.IngressRule, error) {
+	return []networkingv1beta1.IngressRule{testRule("test.example.com")}, nil
+}
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+
+	ing := &networkingv1beta1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-existing-ingress", Namespace: "test"},
+		Spec: networkingv1beta1.IngressSpec{
+			Rules: []networkingv1beta1.IngressRule{testRule("test.example.com")},
+		},
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{ing}...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+func TestGenerateIngress(t *testing.T) {
+	t.Run("generate empty ingress", func(t *testing.T) {
+		expected := &networkingv1beta1.Ingress{TypeMeta: metav1.TypeMeta{
+			Kind:       "Ingress",
+			APIVersion: "networking.k8s.io/v1beta1",
+		}}
+
+		result, err := ingress.GenerateIngress(ingress.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := ingress.GenerateIngress(ingress.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate rules", func(t *testing.T) {
+		result, err := ingress.GenerateIngress(ingress.Conf{
+			GenRulesFunc: func(interfaces.Object) ([]networkingv1beta1.IngressRule, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate tls", func(t *testing.T) {
+		result, err := ingress.GenerateIngress(ingress.Conf{
+			GenTLSFunc: func(interfaces.Object) ([]networkingv1beta1.IngressTLS, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate ingress class", func(t *testing.T) {
+		result, err := ingress.GenerateIngress(ingress.Conf{
+			GenIngressClassFunc: func(interfaces.Object) (string, error) { return "", errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate backend", func(t *testing.T) {
+		result, err := ingress.GenerateIngress(ingress.Conf{
+			GenBackendFunc: func(interfaces.Object) (*networkingv1beta1.IngressBackend, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate ingress", func(t *testing.T) {
+		expected := &networkingv1beta1.Ingress{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Ingress",
+				APIVersion: "networking.k8s.io/v1beta1",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					"nginx.ingress.kubernetes.io/ssl-redirect": "true",
+					ingress.ClassAnnotation:                    "nginx",
+				},
+			},
+			Spec: networkingv1beta1.IngressSpec{
+				Backend: &networkingv1beta1.IngressBackend{ServiceName: "default", ServicePort: intstr.FromString("http")},
+				TLS: []networkingv1beta1.IngressTLS{
+					{SecretName: "test-tls", Hosts: []string{"a.example.com", "b.example.com"}},
+					{SecretName: "other-tls", Hosts: []string{"c.example.com"}},
+				},
+				Rules: []networkingv1beta1.IngressRule{testRule("a.example.com"), testRule("b.example.com")},
+			},
+		}
+
+		result, err := ingress.GenerateIngress(ingress.Conf{
+			GenAnnotationsFunc: func(interfaces.Object) (map[string]string, error) {
+				return map[string]string{"nginx.ingress.kubernetes.io/ssl-redirect": "true"}, nil
+			},
+			GenRulesFunc: func(interfaces.Object) ([]networkingv1beta1.IngressRule, error) {
+				return []networkingv1beta1.IngressRule{testRule("a.example.com"), testRule("b.example.com")}, nil
+			},
+			GenTLSFunc: func(interfaces.Object) ([]networkingv1beta1.IngressTLS, error) {
+				return []networkingv1beta1.IngressTLS{
+					{SecretName: "test-tls"},
+					{SecretName: "other-tls", Hosts: []string{"c.example.com"}},
+				}, nil
+			},
+			GenIngressClassFunc: func(interfaces.Object) (string, error) { return "nginx", nil },
+			GenBackendFunc: func(interfaces.Object) (*networkingv1beta1.IngressBackend, error) {
+				return &networkingv1beta1.IngressBackend{ServiceName: "default", ServicePort: intstr.FromString("http")}, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := ingress.MaybeUpdate(&mocks.MockObject{}, &networkingv1beta1.Ingress{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := ingress.MaybeUpdate(&networkingv1beta1.Ingress{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := ingress.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare ingresses", func(t *testing.T) {
+		t.Run("empty ingresses", func(t *testing.T) {
+			result, err := ingress.MaybeUpdate(&networkingv1beta1.Ingress{}, &networkingv1beta1.Ingress{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("status is ignored", func(t *testing.T) {
+			existingIngress := &networkingv1beta1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ingress.ClassAnnotation: "nginx"}},
+				Status: networkingv1beta1.IngressStatus{LoadBalancer: corev1.LoadBalancerStatus{
+					Ingress: []corev1.LoadBalancerIngress{{IP: "10.0.0.1"}},
+				}},
+			}
+
+			result, err := ingress.MaybeUpdate(existingIngress, &networkingv1beta1.Ingress{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different class", func(t *testing.T) {
+			existingIngress := &networkingv1beta1.Ingress{}
+			newIngress := &networkingv1beta1.Ingress{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{ingress.ClassAnnotation: "nginx"}},
+			}
+
+			result, err := ingress.MaybeUpdate(existingIngress, newIngress)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingIngress, newIngress)
+		})
+		t.Run("different rules", func(t *testing.T) {
+			existingIngress := &networkingv1beta1.Ingress{Spec: networkingv1beta1.IngressSpec{
+				Rules: []networkingv1beta1.IngressRule{testRule("a.example.com")},
+			}}
+			newIngress := &networkingv1beta1.Ingress{Spec: networkingv1beta1.IngressSpec{
+				Rules: []networkingv1beta1.IngressRule{testRule("b.example.com")},
+			}}
+
+			result, err := ingress.MaybeUpdate(existingIngress, newIngress)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingIngress, newIngress)
+		})
+		t.Run("different tls", func(t *testing.T) {
+			existingIngress := &networkingv1beta1.Ingress{Spec: networkingv1beta1.IngressSpec{
+				TLS: []networkingv1beta1.IngressTLS{{SecretName: "test-tls", Hosts: []string{"a.example.com"}}},
+			}}
+			newIngress := &networkingv1beta1.Ingress{Spec: networkingv1beta1.IngressSpec{
+				TLS: []networkingv1beta1.IngressTLS{{SecretName: "renewed-tls", Hosts: []string{"a.example.com"}}},
+			}}
+
+			result, err := ingress.MaybeUpdate(existingIngress, newIngress)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingIngress, newIngress)
+		})
+		t.Run("different backend", func(t *testing.T) {
+			existingIngress := &networkingv1beta1.Ingress{}
+			newIngress := &networkingv1beta1.Ingress{Spec: networkingv1beta1.IngressSpec{
+				Backend: &networkingv1beta1.IngressBackend{ServiceName: "default", ServicePort: intstr.FromInt(80)},
+			}}
+
+			result, err := ingress.MaybeUpdate(existingIngress, newIngress)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingIngress, newIngress)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := ingress.Create(ingress.Conf{GenRulesFunc: func(interfaces.Object) ([]networkingv1beta1.IngressRule, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := ingress.Create(ingress.Conf{GenIngressFunc: func(ingress.Conf) (*networkingv1beta1.Ingress, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := ingress.Create(ingress.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create ingress", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := ingress.Create(ingress.Conf{
+			Instance:     i,
+			Reconcile:    r,
+			GenRulesFunc: testRules,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := ingress.Update(ingress.Conf{GenIngressFunc: func(ingress.Conf) (*networkingv1beta1.Ingress, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := ingress.Update(ingress.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("update ingress", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := ingress.Update(ingress.Conf{
+			Name:         "test-existing-ingress",
+			Namespace:    "test",
+			Instance:     i,
+			Reconcile:    r,
+			GenRulesFunc: testRules,
+			GenTLSFunc: func(interfaces.Object) ([]networkingv1beta1.IngressTLS, error) {
+				return []networkingv1beta1.IngressTLS{{SecretName: "test-tls"}}, nil
+			},
+		})
+		assert.NoError(t, err)
+
+		result := &networkingv1beta1.Ingress{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-ingress", Namespace: "test"}, result)
+		assert.NoError(t, err)
+		assert.Equal(t, []networkingv1beta1.IngressTLS{{SecretName: "test-tls", Hosts: []string{"test.example.com"}}}, result.Spec.TLS)
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := ingress.CreateOrUpdate(ingress.Conf{GenIngressFunc: func(ingress.Conf) (*networkingv1beta1.Ingress, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := ingress.CreateOrUpdate(ingress.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update ingress", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := ingress.CreateOrUpdate(ingress.Conf{
+			Name:         "test-existing-ingress",
+			Namespace:    "test",
+			Instance:     i,
+			Reconcile:    r,
+			GenRulesFunc: testRules,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := ingress.Delete(ingress.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to delete", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := ingress.Delete(ingress.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("delete ingress", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := ingress.Delete(ingress.Conf{
+			Name:      "test-existing-ingress",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
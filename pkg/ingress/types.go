@@ -0,0 +1,85 @@
+package ingress
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	networkingv1beta1 "k8s.io/api/networking/v1beta1"
+)
+
+// ClassAnnotation is the annotation used by Ingress controllers to
+// select the Ingresses they are responsible for.
+const ClassAnnotation = "kubernetes.io/ingress.class"
+
+// GenIngressFunc defines a function which generates Ingress
+type GenIngressFunc func(Conf) (*networkingv1beta1.Ingress, error)
+
+// GenRulesFunc defines a function which generates slice of
+// IngressRule for the Ingress
+type GenRulesFunc func(interfaces.Object) ([]networkingv1beta1.IngressRule, error)
+
+// GenTLSFunc defines a function which generates slice of IngressTLS
+// for the Ingress
+type GenTLSFunc func(interfaces.Object) ([]networkingv1beta1.IngressTLS, error)
+
+// GenIngressClassFunc defines a function which generates the class of
+// the Ingress
+type GenIngressClassFunc func(interfaces.Object) (string, error)
+
+// GenBackendFunc defines a function which generates the default
+// backend for the Ingress
+type GenBackendFunc func(interfaces.Object) (*networkingv1beta1.IngressBackend, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on Ingress objects.
+type Conf struct {
+	// Instance is the Owner object which manages the Ingress
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the Ingress
+	Name string
+	// Namespace of the Ingress
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on Ingress before creating it in cluster
+	OwnerReference bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for Ingress update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the Ingress
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the Ingress
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the Ingress
+	operation.AfterDeleteFunc
+	// GenIngressFunc defines a function to generate the Ingress
+	// object. The package comes with default ingress generator
+	// function which is used by operation functions. By specifying
+	// this field, user can override the default function with a
+	// custom one.
+	GenIngressFunc
+	// GenRulesFunc defines a function to generate host rules for the
+	// Ingress
+	GenRulesFunc
+	// GenTLSFunc defines a function to generate TLS configuration for
+	// the Ingress
+	GenTLSFunc
+	// GenIngressClassFunc defines a function to generate the class
+	// of the Ingress. It is set as ClassAnnotation on the Ingress.
+	GenIngressClassFunc
+	// GenBackendFunc defines a function to generate the default
+	// backend Service for the Ingress
+	GenBackendFunc
+}
@@ -0,0 +1,36 @@
+package mocks
+
+import (
+	"k8s.io/client-go/tools/record"
+)
+
+// RecorderReconcile is the MockReconcile which also implements
+// interfaces.EventRecorder using a FakeRecorder.
+type RecorderReconcile struct {
+	*MockReconcile
+	Recorder *record.FakeRecorder
+}
+
+// NewRecorderReconcile wraps the MockReconcile passed with a
+// FakeRecorder which buffers up to size Events.
+func NewRecorderReconcile(r *MockReconcile, size int) *RecorderReconcile {
+	return &RecorderReconcile{MockReconcile: r, Recorder: record.NewFakeRecorder(size)}
+}
+
+// GetEventRecorder returns the FakeRecorder.
+func (r *RecorderReconcile) GetEventRecorder() record.EventRecorder {
+	return r.Recorder
+}
+
+// Events returns the Events recorded so far.
+func (r *RecorderReconcile) Events() []string {
+	var e []string
+	for {
+		select {
+		case event := <-r.Recorder.Events:
+			e = append(e, event)
+		default:
+			return e
+		}
+	}
+}
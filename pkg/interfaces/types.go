@@ -1,8 +1,10 @@
 package interfaces
 
 import (
+	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -39,3 +41,30 @@ type Reconcile interface {
 	// Getter function for reconcile Scheme
 	GetScheme() *runtime.Scheme
 }
+
+// EventRecorder is the optional interface for Reconcile object
+// structs which record Kubernetes Events. If the Reconcile struct
+// passed to Operatorlib functions implements it, the operations
+// performed on the child objects are recorded as Events of the owner
+// object. They show up in `kubectl describe` of the owner object.
+//
+//     func (r *ReconcileObject) GetEventRecorder() record.EventRecorder { return r.recorder }
+//
+// The recorder can be obtained from the manager using
+// `mgr.GetEventRecorderFor(name)`.
+type EventRecorder interface {
+	// Getter function for reconcile event recorder
+	GetEventRecorder() record.EventRecorder
+}
+
+// Logger is the optional interface for Reconcile object structs which
+// carry a logger. If the Reconcile struct passed to Operatorlib
+// functions implements it, the operations performed on the child
+// objects are logged using the logger, unless the context passed
+// carries one.
+//
+//     func (r *ReconcileObject) GetLogger() logr.Logger { return r.log }
+type Logger interface {
+	// Getter function for reconcile logger
+	GetLogger() logr.Logger
+}
@@ -0,0 +1,3 @@
+// Package job provides functions for manipulating Job object in
+// Kubernetes cluster.
+package job
@@ -0,0 +1,62 @@
+package job_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/job"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	result, err := job.CreateOrUpdate(job.Conf{
+		// Instance is the pointer to owner object under which Job is
+		// being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the Job object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated Job.
+		Name: "job-test",
+		// Recreate tells that the Job should be deleted and created
+		// again if the Pod template changes, since it is immutable.
+		Recreate: true,
+		// GenContainersFunc is the function that generates the
+		// containers for the Pod template.
+		GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) {
+			return []corev1.Container{{Name: "migrate", Image: "migrate:v1"}}, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
+
+func ExampleGetStatus() {
+	status, err := job.GetStatus(job.Conf{
+		Instance:       ownerObject,
+		Reconcile:      ownerReconcile,
+		Name:           "job-test",
+		OwnerReference: true,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch status {
+	case job.StatusSucceeded:
+		log.Print("job has completed")
+	case job.StatusFailed:
+		log.Print("job has failed")
+	case job.StatusRunning:
+		log.Print("job is still running")
+	}
+}
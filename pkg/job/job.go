@@ -0,0 +1,288 @@
+package job
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateJob generates Job object as per the `Conf` struct
+// passed. Jobs do not support `Always` restart policy which is the
+// default for Pods, so if the generated Pod template does not specify
+// the restart policy, `Never` is used.
+func GenerateJob(c Conf) (j *batchv1.Job, err error) {
+	var om *metav1.ObjectMeta
+	var template corev1.PodTemplateSpec
+	var backoffLimit *int32
+	var activeDeadlineSeconds *int64
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenPodTemplateSpecFunc != nil {
+		var t *corev1.PodTemplateSpec
+		t, err = c.GenPodTemplateSpecFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate pod template")
+		}
+		if t != nil {
+			template = *t
+		}
+	}
+
+	if c.GenContainersFunc != nil {
+		template.Spec.Containers, err = c.GenContainersFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate containers")
+		}
+	}
+
+	if c.GenBackoffLimitFunc != nil {
+		backoffLimit, err = c.GenBackoffLimitFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate backoff limit")
+		}
+	}
+
+	if c.GenActiveDeadlineSecondsFunc != nil {
+		activeDeadlineSeconds, err = c.GenActiveDeadlineSecondsFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate active deadline seconds")
+		}
+	}
+
+	if template.Spec.RestartPolicy == "" {
+		template.Spec.RestartPolicy = corev1.RestartPolicyNever
+	}
+
+	j = &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Job",
+			APIVersion: "batch/v1",
+		},
+		ObjectMeta: *om,
+		Spec: batchv1.JobSpec{
+			Template:              template,
+			BackoffLimit:          backoffLimit,
+			ActiveDeadlineSeconds: activeDeadlineSeconds,
+		},
+	}
+
+	return j, nil
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for Job object. It compares
+// the two Jobs being passed and update the first one if
+// required. Pod template of the Job is immutable so it returns
+// operation.ImmutableFieldError if that is different. API Server adds
+// labels and fills in defaults in the Pod template, so only the
+// fields set in the generated Job are compared. Backoff limit and
+// active deadline are only compared if set.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	oj, ok := original.(*batchv1.Job)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	nj, ok := new.(*batchv1.Job)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	if !operation.DeepDerivative(nj.Spec.Template, oj.Spec.Template) {
+		return false, operation.NewImmutableFieldError("Job", "spec.template")
+	}
+
+	update := false
+
+	if nj.Spec.BackoffLimit != nil && !equality.Semantic.DeepEqual(oj.Spec.BackoffLimit, nj.Spec.BackoffLimit) {
+		oj.Spec.BackoffLimit = nj.Spec.BackoffLimit
+		update = true
+	}
+
+	if nj.Spec.ActiveDeadlineSeconds != nil && !equality.Semantic.DeepEqual(oj.Spec.ActiveDeadlineSeconds, nj.Spec.ActiveDeadlineSeconds) {
+		oj.Spec.ActiveDeadlineSeconds = nj.Spec.ActiveDeadlineSeconds
+		update = true
+	}
+
+	return update, nil
+}
+
+// Create generates the Job as per the `Conf` struct passed and
+// creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var j *batchv1.Job
+	var err error
+	if c.GenJobFunc != nil {
+		j, err = c.GenJobFunc(c)
+	} else {
+		j, err = GenerateJob(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate job")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          j,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create job")
+	}
+
+	return result, nil
+}
+
+// Update generates the Job as per the `Conf` struct passed and
+// compares it with the in-cluster version. If required, it updates
+// the in-cluster Job with the changes. For comparing the Jobs, it
+// uses `MaybeUpdate` function by default but can also use
+// `MaybeUpdateFunc` from `Conf` if passed. If `Recreate` is set in
+// `Conf` and the comparison reports a change in an immutable field,
+// the in-cluster Job is deleted along with its Pods and created again.
+func Update(c Conf) (reconcile.Result, error) {
+	var j *batchv1.Job
+	var err error
+	if c.GenJobFunc != nil {
+		j, err = c.GenJobFunc(c)
+	} else {
+		j, err = GenerateJob(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate job")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          j,
+		ExistingObject:  &batchv1.Job{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if c.Recreate && operation.IsImmutableFieldError(err) {
+		result, err = operation.Recreate(operation.Conf{
+			Instance:        c.Instance,
+			Reconcile:       c.Reconcile,
+			Object:          j,
+			OwnerReference:  c.OwnerReference,
+			AfterCreateFunc: c.AfterCreateFunc,
+			// Deleting a Job orphans its Pods by default, so let the
+			// garbage collector clean them up.
+			PropagationPolicy: metav1.DeletePropagationBackground,
+		})
+		return result, errors.Wrap(err, "failed to recreate job")
+	}
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update job")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update`
+// functions. It creates the Job object if it is not already in the
+// cluster and updates the Job if one exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var j *batchv1.Job
+	var err error
+	if c.GenJobFunc != nil {
+		j, err = c.GenJobFunc(c)
+	} else {
+		j, err = GenerateJob(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate job")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          j,
+		ExistingObject:  &batchv1.Job{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if c.Recreate && operation.IsImmutableFieldError(err) {
+		result, err = operation.Recreate(operation.Conf{
+			Instance:        c.Instance,
+			Reconcile:       c.Reconcile,
+			Object:          j,
+			OwnerReference:  c.OwnerReference,
+			AfterCreateFunc: c.AfterCreateFunc,
+			// Deleting a Job orphans its Pods by default, so let the
+			// garbage collector clean them up.
+			PropagationPolicy: metav1.DeletePropagationBackground,
+		})
+		return result, errors.Wrap(err, "failed to recreate job")
+	}
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update job")
+	}
+
+	return result, nil
+}
+
+// Delete generates the ObjectMeta for Job as per the `Conf` struct
+// passed and deletes it from the cluster
+func Delete(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for job")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &batchv1.Job{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete job")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,445 @@
+package job_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/job"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const ownerUID = types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func int64Ptr(i int64) *int64 { return &i }
+
+func testContainers(image string) func(interfaces.Object) ([]corev1.Container, error) {
+	return func(interfaces.Object) ([]corev1.Container, error) {
+		return []corev1.Container{{Name: "test", Image: image}}, nil
+	}
+}
+
+func testJob(name string, owned bool, conditions ...batchv1.JobCondition) *batchv1.Job {
+	j := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test"},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers:    []corev1.Container{{Name: "test", Image: "test:v1"}},
+				},
+			},
+		},
+		Status: batchv1.JobStatus{Conditions: conditions},
+	}
+
+	if owned {
+		controller := true
+		j.OwnerReferences = []metav1.OwnerReference{{Name: "test", UID: ownerUID, Controller: &controller}}
+	}
+
+	return j
+}
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(ownerUID).AnyTimes()
+
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{
+		testJob("test-existing-job", true),
+		testJob("test-succeeded-job", true, batchv1.JobCondition{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}),
+		testJob("test-failed-job", true, batchv1.JobCondition{Type: batchv1.JobFailed, Status: corev1.ConditionTrue}),
+		testJob("test-unowned-job", false),
+	}...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+func TestGenerateJob(t *testing.T) {
+	t.Run("generate empty job", func(t *testing.T) {
+		expected := &batchv1.Job{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Job",
+				APIVersion: "batch/v1",
+			},
+			Spec: batchv1.JobSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{RestartPolicy: corev1.RestartPolicyNever}},
+			},
+		}
+
+		result, err := job.GenerateJob(job.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := job.GenerateJob(job.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate pod template", func(t *testing.T) {
+		result, err := job.GenerateJob(job.Conf{
+			GenPodTemplateSpecFunc: func(interfaces.Object) (*corev1.PodTemplateSpec, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate containers", func(t *testing.T) {
+		result, err := job.GenerateJob(job.Conf{
+			GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate backoff limit", func(t *testing.T) {
+		result, err := job.GenerateJob(job.Conf{
+			GenBackoffLimitFunc: func(interfaces.Object) (*int32, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate active deadline seconds", func(t *testing.T) {
+		result, err := job.GenerateJob(job.Conf{
+			GenActiveDeadlineSecondsFunc: func(interfaces.Object) (*int64, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate job", func(t *testing.T) {
+		expected := &batchv1.Job{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Job",
+				APIVersion: "batch/v1",
+			},
+			Spec: batchv1.JobSpec{
+				BackoffLimit:          int32Ptr(2),
+				ActiveDeadlineSeconds: int64Ptr(60),
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					Containers:    []corev1.Container{{Name: "test", Image: "test:v1"}},
+				}},
+			},
+		}
+
+		result, err := job.GenerateJob(job.Conf{
+			GenPodTemplateSpecFunc: func(interfaces.Object) (*corev1.PodTemplateSpec, error) {
+				return &corev1.PodTemplateSpec{Spec: corev1.PodSpec{RestartPolicy: corev1.RestartPolicyOnFailure}}, nil
+			},
+			GenContainersFunc:            testContainers("test:v1"),
+			GenBackoffLimitFunc:          func(interfaces.Object) (*int32, error) { return int32Ptr(2), nil },
+			GenActiveDeadlineSecondsFunc: func(interfaces.Object) (*int64, error) { return int64Ptr(60), nil },
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := job.MaybeUpdate(&mocks.MockObject{}, &batchv1.Job{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := job.MaybeUpdate(&batchv1.Job{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := job.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare jobs", func(t *testing.T) {
+		t.Run("empty jobs", func(t *testing.T) {
+			result, err := job.MaybeUpdate(&batchv1.Job{}, &batchv1.Job{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different pod template", func(t *testing.T) {
+			existingJob := testJob("test", false)
+			newJob := testJob("test", false)
+			newJob.Spec.Template.Spec.Containers[0].Image = "test:v2"
+
+			result, err := job.MaybeUpdate(existingJob, newJob)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+		t.Run("removed container and environment variable", func(t *testing.T) {
+			existingJob := testJob("test", false)
+			existingJob.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "FOO", Value: "foo"}, {Name: "BAR", Value: "bar"}}
+			existingJob.Spec.Template.Spec.Containers = append(existingJob.Spec.Template.Spec.Containers, corev1.Container{Name: "sidecar", Image: "sidecar:v1"})
+			newJob := testJob("test", false)
+			newJob.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "FOO", Value: "foo"}}
+
+			result, err := job.MaybeUpdate(existingJob, newJob)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+		t.Run("labels added by api server", func(t *testing.T) {
+			existingJob := testJob("test", false)
+			existingJob.Spec.Template.Labels = map[string]string{"job-name": "test"}
+			newJob := testJob("test", false)
+
+			result, err := job.MaybeUpdate(existingJob, newJob)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different backoff limit", func(t *testing.T) {
+			existingJob := &batchv1.Job{Spec: batchv1.JobSpec{BackoffLimit: int32Ptr(6)}}
+			newJob := &batchv1.Job{Spec: batchv1.JobSpec{BackoffLimit: int32Ptr(2)}}
+
+			result, err := job.MaybeUpdate(existingJob, newJob)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingJob, newJob)
+		})
+		t.Run("different active deadline seconds", func(t *testing.T) {
+			existingJob := &batchv1.Job{Spec: batchv1.JobSpec{ActiveDeadlineSeconds: int64Ptr(30)}}
+			newJob := &batchv1.Job{Spec: batchv1.JobSpec{ActiveDeadlineSeconds: int64Ptr(60)}}
+
+			result, err := job.MaybeUpdate(existingJob, newJob)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingJob, newJob)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := job.Create(job.Conf{GenBackoffLimitFunc: func(interfaces.Object) (*int32, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := job.Create(job.Conf{GenJobFunc: func(job.Conf) (*batchv1.Job, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := job.Create(job.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create job", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := job.Create(job.Conf{
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := job.Update(job.Conf{GenBackoffLimitFunc: func(interfaces.Object) (*int32, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := job.Update(job.Conf{GenJobFunc: func(job.Conf) (*batchv1.Job, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("update job", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := job.Update(job.Conf{
+			Name:                "test-existing-job",
+			Namespace:           "test",
+			Instance:            i,
+			Reconcile:           r,
+			GenContainersFunc:   testContainers("test:v1"),
+			GenBackoffLimitFunc: func(interfaces.Object) (*int32, error) { return int32Ptr(2), nil },
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("pod template changed", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := job.Update(job.Conf{
+			Name:              "test-existing-job",
+			Namespace:         "test",
+			Instance:          i,
+			Reconcile:         r,
+			GenContainersFunc: testContainers("test:v2"),
+		})
+		assert.True(t, operation.IsImmutableFieldError(err))
+	})
+	t.Run("pod template changed with recreate", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := job.Update(job.Conf{
+			Name:              "test-existing-job",
+			Namespace:         "test",
+			Instance:          i,
+			Reconcile:         r,
+			OwnerReference:    true,
+			Recreate:          true,
+			GenContainersFunc: testContainers("test:v2"),
+		})
+		assert.NoError(t, err)
+
+		result := &batchv1.Job{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-job", Namespace: "test"}, result)
+		assert.NoError(t, err)
+		assert.Equal(t, "test:v2", result.Spec.Template.Spec.Containers[0].Image)
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := job.CreateOrUpdate(job.Conf{GenBackoffLimitFunc: func(interfaces.Object) (*int32, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := job.CreateOrUpdate(job.Conf{GenJobFunc: func(job.Conf) (*batchv1.Job, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("create job", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := job.CreateOrUpdate(job.Conf{
+			Name:              "test-job",
+			Namespace:         "test",
+			Instance:          i,
+			Reconcile:         r,
+			GenContainersFunc: testContainers("test:v1"),
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("pod template changed", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := job.CreateOrUpdate(job.Conf{
+			Name:              "test-existing-job",
+			Namespace:         "test",
+			Instance:          i,
+			Reconcile:         r,
+			GenContainersFunc: testContainers("test:v2"),
+		})
+		assert.True(t, operation.IsImmutableFieldError(err))
+	})
+	t.Run("pod template changed with recreate", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := job.CreateOrUpdate(job.Conf{
+			Name:              "test-existing-job",
+			Namespace:         "test",
+			Instance:          i,
+			Reconcile:         r,
+			OwnerReference:    true,
+			Recreate:          true,
+			GenContainersFunc: testContainers("test:v2"),
+		})
+		assert.NoError(t, err)
+
+		result := &batchv1.Job{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-job", Namespace: "test"}, result)
+		assert.NoError(t, err)
+		assert.Equal(t, "test:v2", result.Spec.Template.Spec.Containers[0].Image)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := job.Delete(job.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("delete job", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := job.Delete(job.Conf{
+			Name:      "test-existing-job",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestGetStatus(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("job does not exist", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := job.GetStatus(job.Conf{Name: "test-job", Namespace: "test", Instance: i, Reconcile: r})
+		assert.Error(t, err)
+	})
+	t.Run("job is not owned by instance", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := job.GetStatus(job.Conf{Name: "test-unowned-job", Namespace: "test", Instance: i, Reconcile: r, OwnerReference: true})
+		assert.Error(t, err)
+	})
+	t.Run("running job", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		status, err := job.GetStatus(job.Conf{Name: "test-existing-job", Namespace: "test", Instance: i, Reconcile: r, OwnerReference: true})
+		assert.NoError(t, err)
+		assert.Equal(t, job.StatusRunning, status)
+	})
+	t.Run("succeeded job", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		status, err := job.GetStatus(job.Conf{Name: "test-succeeded-job", Namespace: "test", Instance: i, Reconcile: r, OwnerReference: true})
+		assert.NoError(t, err)
+		assert.Equal(t, job.StatusSucceeded, status)
+	})
+	t.Run("failed job", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		status, err := job.GetStatus(job.Conf{Name: "test-failed-job", Namespace: "test", Instance: i, Reconcile: r, OwnerReference: true})
+		assert.NoError(t, err)
+		assert.Equal(t, job.StatusFailed, status)
+	})
+}
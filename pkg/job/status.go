@@ -0,0 +1,44 @@
+package job
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// GetStatus fetches the Job defined by the `Conf` struct passed from
+// the cluster and reports if it has succeeded, failed or is still
+// running. If `OwnerReference` is set in `Conf`, it also verifies
+// that the Job is controlled by the Instance and returns error
+// otherwise, so that a Job with the same name created by someone else
+// is not mistaken for the one owned by the Instance.
+func GetStatus(c Conf) (Status, error) {
+	j := &batchv1.Job{}
+	err := c.Reconcile.GetClient().Get(context.TODO(), types.NamespacedName{Name: c.Name, Namespace: c.Namespace}, j)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the job from cluster")
+	}
+
+	if c.OwnerReference && !metav1.IsControlledBy(j, c.Instance) {
+		return "", errors.New("job is not owned by the instance")
+	}
+
+	for _, condition := range j.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return StatusSucceeded, nil
+		case batchv1.JobFailed:
+			return StatusFailed, nil
+		}
+	}
+
+	return StatusRunning, nil
+}
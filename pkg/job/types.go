@@ -0,0 +1,100 @@
+package job
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GenJobFunc defines a function which generates Job
+type GenJobFunc func(Conf) (*batchv1.Job, error)
+
+// GenPodTemplateSpecFunc defines a function which generates
+// PodTemplateSpec for the Job
+type GenPodTemplateSpecFunc func(interfaces.Object) (*corev1.PodTemplateSpec, error)
+
+// GenContainersFunc defines a function which generates slice of
+// Container for the Pod template of the Job
+type GenContainersFunc func(interfaces.Object) ([]corev1.Container, error)
+
+// GenBackoffLimitFunc defines a function which generates number of
+// retries before marking the Job failed
+type GenBackoffLimitFunc func(interfaces.Object) (*int32, error)
+
+// GenActiveDeadlineSecondsFunc defines a function which generates the
+// duration in seconds for which the Job may be active
+type GenActiveDeadlineSecondsFunc func(interfaces.Object) (*int64, error)
+
+// Status is the state of the Job in the cluster
+type Status string
+
+const (
+	// StatusRunning means that the Job has neither completed nor
+	// failed yet
+	StatusRunning Status = "Running"
+	// StatusSucceeded means that the Job has completed successfully
+	StatusSucceeded Status = "Succeeded"
+	// StatusFailed means that the Job has failed
+	StatusFailed Status = "Failed"
+)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on Job objects.
+type Conf struct {
+	// Instance is the Owner object which manages the Job
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the Job
+	Name string
+	// Namespace of the Job
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on Job before creating it in cluster. It is also used by
+	// GetStatus to verify that the Job is owned by the Instance.
+	OwnerReference bool
+	// Recreate is used to determine if the Job should be deleted and
+	// created again when the Pod template or any other immutable
+	// field is changed, instead of returning error on Update.
+	Recreate bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for Job update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the Job
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the Job
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the Job
+	operation.AfterDeleteFunc
+	// GenJobFunc defines a function to generate the Job object. The
+	// package comes with default job generator function which is
+	// used by operation functions. By specifying this field, user can
+	// override the default function with a custom one.
+	GenJobFunc
+	// GenPodTemplateSpecFunc defines a function to generate the Pod
+	// template for the Job
+	GenPodTemplateSpecFunc
+	// GenContainersFunc defines a function to generate containers
+	// for the Pod template. If specified, the generated containers
+	// replace the ones in the generated Pod template.
+	GenContainersFunc
+	// GenBackoffLimitFunc defines a function to generate backoff
+	// limit for the Job
+	GenBackoffLimitFunc
+	// GenActiveDeadlineSecondsFunc defines a function to generate
+	// active deadline for the Job
+	GenActiveDeadlineSecondsFunc
+}
@@ -0,0 +1,3 @@
+// Package logging provides the logger used by the functions of this
+// library and the keys and verbosity levels of the logs they write.
+package logging
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"context"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	"github.com/go-logr/logr"
+)
+
+// Keys of the values logged by the operations on the objects.
+const (
+	// KindKey is the key of the kind of the object.
+	KindKey = "kind"
+	// NamespaceKey is the key of the namespace of the object.
+	NamespaceKey = "namespace"
+	// NameKey is the key of the name of the object.
+	NameKey = "name"
+	// ActionKey is the key of the action taken on the object, like
+	// create, update, delete or noop.
+	ActionKey = "action"
+	// DurationKey is the key of the time taken by the operation.
+	DurationKey = "duration"
+	// UpdateRequestedKey is the key of the flag which tells if the
+	// update function asked for the object to be updated.
+	UpdateRequestedKey = "updateRequested"
+	// DryRunKey is the key of the flag which tells if the operation
+	// is performed in dry-run mode. It is only logged if set.
+	DryRunKey = "dryRun"
+	// VetoedKey is the key of the flag which tells if the operation
+	// is vetoed by a hook. It is only logged if set.
+	VetoedKey = "vetoed"
+	// DiffKey is the key of the fields changed by update.
+	DiffKey = "diff"
+	// ErrorKey is the key of the error of the operations which are
+	// not logged as errors, like conflicts.
+	ErrorKey = "error"
+)
+
+// Verbosity levels of the logs. Failures are logged as errors.
+const (
+	// ChangeLevel is the verbosity of the logs of the changes made to
+	// the cluster.
+	ChangeLevel = 1
+	// DebugLevel is the verbosity of the logs of the operations which
+	// left the cluster as it is.
+	DebugLevel = 2
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of the context which carries the logger
+// passed. The functions of this library called with the context log
+// using the logger.
+func NewContext(ctx context.Context, logger logr.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by the context, or nil if
+// there is none.
+func FromContext(ctx context.Context) logr.Logger {
+	logger, _ := ctx.Value(contextKey{}).(logr.Logger)
+	return logger
+}
+
+// Get returns the logger from the context if it carries one, or else
+// the one from the Reconcile struct if it implements
+// interfaces.Logger. If neither has a logger, the logs are discarded.
+func Get(ctx context.Context, r interfaces.Reconcile) logr.Logger {
+	if logger := FromContext(ctx); logger != nil {
+		return logger
+	}
+
+	if rl, ok := r.(interfaces.Logger); ok && rl.GetLogger() != nil {
+		return rl.GetLogger()
+	}
+
+	return Discard()
+}
+
+// Discard returns the logger which discards all the logs.
+func Discard() logr.Logger {
+	return discard{}
+}
+
+type discard struct{}
+
+func (discard) Info(string, ...interface{})             {}
+func (discard) Enabled() bool                           { return false }
+func (discard) Error(error, string, ...interface{})     {}
+func (d discard) V(int) logr.InfoLogger                 { return d }
+func (d discard) WithValues(...interface{}) logr.Logger { return d }
+func (d discard) WithName(string) logr.Logger           { return d }
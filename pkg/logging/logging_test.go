@@ -0,0 +1,75 @@
+package logging_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/logging"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// namedLogger is the logger which can be told apart from others by
+// its name.
+type namedLogger struct {
+	logr.Logger
+	name string
+}
+
+// loggerReconcile is the reconcile struct which also implements
+// interfaces.Logger.
+type loggerReconcile struct {
+	*mocks.MockReconcile
+	logger logr.Logger
+}
+
+func (r *loggerReconcile) GetLogger() logr.Logger {
+	return r.logger
+}
+
+func TestContext(t *testing.T) {
+	assert.Nil(t, logging.FromContext(context.TODO()))
+
+	logger := &namedLogger{name: "context"}
+	ctx := logging.NewContext(context.TODO(), logger)
+	assert.Equal(t, logger, logging.FromContext(ctx))
+}
+
+func TestGet(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	contextLogger := &namedLogger{name: "context"}
+	reconcileLogger := &namedLogger{name: "reconcile"}
+	ctx := logging.NewContext(context.TODO(), contextLogger)
+
+	t.Run("logger from context", func(t *testing.T) {
+		r := &loggerReconcile{MockReconcile: mocks.NewMockReconcile(controller), logger: reconcileLogger}
+		assert.Equal(t, contextLogger, logging.Get(ctx, r))
+	})
+	t.Run("logger from reconcile", func(t *testing.T) {
+		r := &loggerReconcile{MockReconcile: mocks.NewMockReconcile(controller), logger: reconcileLogger}
+		assert.Equal(t, reconcileLogger, logging.Get(context.TODO(), r))
+	})
+	t.Run("reconcile without logger", func(t *testing.T) {
+		r := &loggerReconcile{MockReconcile: mocks.NewMockReconcile(controller)}
+		assert.Equal(t, logging.Discard(), logging.Get(context.TODO(), r))
+	})
+	t.Run("reconcile without logger interface", func(t *testing.T) {
+		r := mocks.NewMockReconcile(controller)
+		assert.Equal(t, logging.Discard(), logging.Get(context.TODO(), r))
+	})
+}
+
+func TestDiscard(t *testing.T) {
+	logger := logging.Discard()
+	assert.False(t, logger.Enabled())
+	assert.False(t, logger.V(logging.ChangeLevel).Enabled())
+	assert.NotPanics(t, func() {
+		logger.WithName("test").WithValues("key", "value").Info("test")
+		logger.Error(nil, "test")
+	})
+}
@@ -0,0 +1,6 @@
+// Package networkpolicy provides functions for manipulating
+// NetworkPolicy object in Kubernetes cluster. Apart from the usual
+// functions, the package also comes with stock generators for the
+// commonly used policies like denying all the ingress traffic or
+// allowing the traffic from the Pods of the owner object.
+package networkpolicy
@@ -0,0 +1,52 @@
+package networkpolicy_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/networkpolicy"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	// Deny all the ingress traffic in the namespace using the stock
+	// generator.
+	result, err := networkpolicy.CreateOrUpdate(networkpolicy.Conf{
+		Instance:             ownerObject,
+		OwnerReference:       true,
+		Reconcile:            ownerReconcile,
+		Name:                 "deny-all-ingress",
+		GenNetworkPolicyFunc: networkpolicy.GenerateDenyAllIngress,
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+
+	// Allow the traffic between the Pods having the same labels as
+	// the owner object.
+	result, err = networkpolicy.CreateOrUpdate(networkpolicy.Conf{
+		// Instance is the pointer to owner object under which
+		// NetworkPolicy is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the NetworkPolicy object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated NetworkPolicy.
+		Name: "allow-from-instance",
+		// GenPodSelectorFunc is the function that generates the
+		// selector for the Pods the NetworkPolicy applies to.
+		GenPodSelectorFunc: networkpolicy.SelectPods(networkpolicy.InstanceLabels),
+		// GenIngressRulesFunc is the function that generates the
+		// rules allowing the ingress traffic.
+		GenIngressRulesFunc: networkpolicy.AllowFromPods(networkpolicy.InstanceLabels),
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
@@ -0,0 +1,94 @@
+package networkpolicy
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+
+	"github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GenerateDenyAllIngress generates NetworkPolicy which denies all the
+// ingress traffic to the Pods in the namespace. It implements
+// GenNetworkPolicyFunc and only uses the ObjectMeta related fields of
+// the `Conf` struct passed. Traffic can then be allowed by separate
+// NetworkPolicies with ingress rules, since the policies are
+// additive.
+func GenerateDenyAllIngress(c Conf) (*networkingv1.NetworkPolicy, error) {
+	return GenerateNetworkPolicy(Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+		GenPolicyTypesFunc: PolicyTypes(networkingv1.PolicyTypeIngress),
+	})
+}
+
+// GenerateDenyAllEgress generates NetworkPolicy which denies all the
+// egress traffic from the Pods in the namespace. Similar to
+// GenerateDenyAllIngress, it only uses the ObjectMeta related fields
+// of the `Conf` struct passed.
+func GenerateDenyAllEgress(c Conf) (*networkingv1.NetworkPolicy, error) {
+	return GenerateNetworkPolicy(Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+		GenPolicyTypesFunc: PolicyTypes(networkingv1.PolicyTypeEgress),
+	})
+}
+
+// PolicyTypes returns GenPolicyTypesFunc which always generates the
+// policy types passed.
+func PolicyTypes(types ...networkingv1.PolicyType) GenPolicyTypesFunc {
+	return func(interfaces.Object) ([]networkingv1.PolicyType, error) {
+		return types, nil
+	}
+}
+
+// InstanceLabels implements meta.GenLabelsFunc and generates the
+// labels of the owner object. It can be used with the generators
+// below to select the Pods having the same labels as the owner
+// object.
+func InstanceLabels(o interfaces.Object) (map[string]string, error) {
+	return o.GetLabels(), nil
+}
+
+// SelectPods returns GenPodSelectorFunc which selects the Pods having
+// the labels generated by the meta.GenLabelsFunc passed.
+func SelectPods(genLabelsFunc meta.GenLabelsFunc) GenPodSelectorFunc {
+	return func(o interfaces.Object) (metav1.LabelSelector, error) {
+		labels, err := genLabelsFunc(o)
+		if err != nil {
+			return metav1.LabelSelector{}, errors.Wrap(err, "failed to generate labels for pod selector")
+		}
+
+		return metav1.LabelSelector{MatchLabels: labels}, nil
+	}
+}
+
+// AllowFromPods returns GenIngressRulesFunc which allows the ingress
+// traffic from the Pods in the same namespace having the labels
+// generated by the meta.GenLabelsFunc passed. Use InstanceLabels to
+// allow the traffic from the Pods with the owner object's labels.
+func AllowFromPods(genLabelsFunc meta.GenLabelsFunc) GenIngressRulesFunc {
+	return func(o interfaces.Object) ([]networkingv1.NetworkPolicyIngressRule, error) {
+		labels, err := genLabelsFunc(o)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate labels for ingress rule")
+		}
+
+		return []networkingv1.NetworkPolicyIngressRule{{
+			From: []networkingv1.NetworkPolicyPeer{{
+				PodSelector: &metav1.LabelSelector{MatchLabels: labels},
+			}},
+		}}, nil
+	}
+}
@@ -0,0 +1,100 @@
+package networkpolicy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/networkpolicy"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestGenerateDenyAllIngress(t *testing.T) {
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := networkpolicy.GenerateDenyAllIngress(networkpolicy.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate deny all ingress", func(t *testing.T) {
+		expected := &networkingv1.NetworkPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "NetworkPolicy",
+				APIVersion: "networking.k8s.io/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "deny-all", Namespace: "test"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			},
+		}
+
+		result, err := networkpolicy.GenerateDenyAllIngress(networkpolicy.Conf{
+			Name:      "deny-all",
+			Namespace: "test",
+			// Rules are ignored by the stock generator.
+			GenIngressRulesFunc: func(interfaces.Object) ([]networkingv1.NetworkPolicyIngressRule, error) {
+				return []networkingv1.NetworkPolicyIngressRule{{}}, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestGenerateDenyAllEgress(t *testing.T) {
+	t.Run("generate deny all egress", func(t *testing.T) {
+		result, err := networkpolicy.GenerateDenyAllEgress(networkpolicy.Conf{Name: "deny-all"})
+		assert.NoError(t, err)
+		assert.Equal(t, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}, result.Spec.PolicyTypes)
+		assert.Empty(t, result.Spec.Egress)
+		assert.Empty(t, result.Spec.PodSelector)
+	})
+}
+
+func TestSelectPods(t *testing.T) {
+	t.Run("failed to generate labels", func(t *testing.T) {
+		_, err := networkpolicy.SelectPods(func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		})(nil)
+		assert.Error(t, err)
+	})
+	t.Run("select pods", func(t *testing.T) {
+		result, err := networkpolicy.SelectPods(func(interfaces.Object) (map[string]string, error) {
+			return map[string]string{"app": "test"}, nil
+		})(nil)
+		assert.NoError(t, err)
+		assert.Equal(t, metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, result)
+	})
+}
+
+func TestAllowFromPods(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate labels", func(t *testing.T) {
+		_, err := networkpolicy.AllowFromPods(func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		})(nil)
+		assert.Error(t, err)
+	})
+	t.Run("allow from pods with instance labels", func(t *testing.T) {
+		i := mocks.NewMockObject(controller)
+		i.EXPECT().GetLabels().Return(map[string]string{"app": "test"})
+
+		expected := []networkingv1.NetworkPolicyIngressRule{{
+			From: []networkingv1.NetworkPolicyPeer{{
+				PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			}},
+		}}
+
+		result, err := networkpolicy.AllowFromPods(networkpolicy.InstanceLabels)(i)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
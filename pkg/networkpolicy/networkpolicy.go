@@ -0,0 +1,257 @@
+package networkpolicy
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateNetworkPolicy generates NetworkPolicy object as per the
+// `Conf` struct passed
+func GenerateNetworkPolicy(c Conf) (np *networkingv1.NetworkPolicy, err error) {
+	var om *metav1.ObjectMeta
+	var podSelector metav1.LabelSelector
+	var ingress []networkingv1.NetworkPolicyIngressRule
+	var egress []networkingv1.NetworkPolicyEgressRule
+	var policyTypes []networkingv1.PolicyType
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenPodSelectorFunc != nil {
+		podSelector, err = c.GenPodSelectorFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate pod selector")
+		}
+	}
+
+	if c.GenIngressRulesFunc != nil {
+		ingress, err = c.GenIngressRulesFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate ingress rules")
+		}
+	}
+
+	if c.GenEgressRulesFunc != nil {
+		egress, err = c.GenEgressRulesFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate egress rules")
+		}
+	}
+
+	if c.GenPolicyTypesFunc != nil {
+		policyTypes, err = c.GenPolicyTypesFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate policy types")
+		}
+	}
+
+	np = &networkingv1.NetworkPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		},
+		ObjectMeta: *om,
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: podSelector,
+			Ingress:     ingress,
+			Egress:      egress,
+			PolicyTypes: policyTypes,
+		},
+	}
+
+	return np, nil
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for NetworkPolicy object. It
+// compares the two NetworkPolicies being passed and update the first
+// one if required. API Server defaults the protocol of the ports in
+// the rules, so only the fields set in the generated rules are
+// compared, though the peers, ports and rules removed from the
+// generated rules are detected. Policy types are defaulted as well
+// and are only compared if set.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	onp, ok := original.(*networkingv1.NetworkPolicy)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	nnp, ok := new.(*networkingv1.NetworkPolicy)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	update := false
+
+	if !equality.Semantic.DeepEqual(onp.Spec.PodSelector, nnp.Spec.PodSelector) {
+		onp.Spec.PodSelector = nnp.Spec.PodSelector
+		update = true
+	}
+
+	if !operation.DeepDerivative(nnp.Spec.Ingress, onp.Spec.Ingress) {
+		onp.Spec.Ingress = nnp.Spec.Ingress
+		update = true
+	}
+
+	if !operation.DeepDerivative(nnp.Spec.Egress, onp.Spec.Egress) {
+		onp.Spec.Egress = nnp.Spec.Egress
+		update = true
+	}
+
+	if len(nnp.Spec.PolicyTypes) != 0 && !equality.Semantic.DeepEqual(onp.Spec.PolicyTypes, nnp.Spec.PolicyTypes) {
+		onp.Spec.PolicyTypes = nnp.Spec.PolicyTypes
+		update = true
+	}
+
+	return update, nil
+}
+
+// Create generates the NetworkPolicy as per the `Conf` struct passed
+// and creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var np *networkingv1.NetworkPolicy
+	var err error
+	if c.GenNetworkPolicyFunc != nil {
+		np, err = c.GenNetworkPolicyFunc(c)
+	} else {
+		np, err = GenerateNetworkPolicy(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate networkpolicy")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          np,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create networkpolicy")
+	}
+
+	return result, nil
+}
+
+// Update generates the NetworkPolicy as per the `Conf` struct passed
+// and compares it with the in-cluster version. If required, it
+// updates the in-cluster NetworkPolicy with the changes. For
+// comparing the NetworkPolicies, it uses `MaybeUpdate` function by
+// default but can also use `MaybeUpdateFunc` from `Conf` if passed.
+func Update(c Conf) (reconcile.Result, error) {
+	var np *networkingv1.NetworkPolicy
+	var err error
+	if c.GenNetworkPolicyFunc != nil {
+		np, err = c.GenNetworkPolicyFunc(c)
+	} else {
+		np, err = GenerateNetworkPolicy(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate networkpolicy")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          np,
+		ExistingObject:  &networkingv1.NetworkPolicy{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update networkpolicy")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update` functions.
+// It creates the NetworkPolicy object if it is not already in the
+// cluster and updates the NetworkPolicy if one exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var np *networkingv1.NetworkPolicy
+	var err error
+	if c.GenNetworkPolicyFunc != nil {
+		np, err = c.GenNetworkPolicyFunc(c)
+	} else {
+		np, err = GenerateNetworkPolicy(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate networkpolicy")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          np,
+		ExistingObject:  &networkingv1.NetworkPolicy{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update networkpolicy")
+	}
+
+	return result, nil
+}
+
+// Delete generates the ObjectMeta for NetworkPolicy as per the `Conf`
+// struct passed and deletes it from the cluster
+func Delete(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for networkpolicy")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &networkingv1.NetworkPolicy{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete networkpolicy")
+	}
+
+	return result, nil
+}
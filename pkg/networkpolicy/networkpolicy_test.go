@@ -0,0 +1,446 @@
+package networkpolicy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/networkpolicy"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+	i.EXPECT().GetLabels().Return(map[string]string{"app": "test"}).AnyTimes()
+
+	np := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-existing-networkpolicy", Namespace: "test"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{np}...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+func TestGenerateNetworkPolicy(t *testing.T) {
+	t.Run("generate empty networkpolicy", func(t *testing.T) {
+		expected := &networkingv1.NetworkPolicy{TypeMeta: metav1.TypeMeta{
+			Kind:       "NetworkPolicy",
+			APIVersion: "networking.k8s.io/v1",
+		}}
+
+		result, err := networkpolicy.GenerateNetworkPolicy(networkpolicy.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := networkpolicy.GenerateNetworkPolicy(networkpolicy.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate pod selector", func(t *testing.T) {
+		result, err := networkpolicy.GenerateNetworkPolicy(networkpolicy.Conf{
+			GenPodSelectorFunc: func(interfaces.Object) (metav1.LabelSelector, error) {
+				return metav1.LabelSelector{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate ingress rules", func(t *testing.T) {
+		result, err := networkpolicy.GenerateNetworkPolicy(networkpolicy.Conf{
+			GenIngressRulesFunc: func(interfaces.Object) ([]networkingv1.NetworkPolicyIngressRule, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate egress rules", func(t *testing.T) {
+		result, err := networkpolicy.GenerateNetworkPolicy(networkpolicy.Conf{
+			GenEgressRulesFunc: func(interfaces.Object) ([]networkingv1.NetworkPolicyEgressRule, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate policy types", func(t *testing.T) {
+		result, err := networkpolicy.GenerateNetworkPolicy(networkpolicy.Conf{
+			GenPolicyTypesFunc: func(interfaces.Object) ([]networkingv1.PolicyType, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate networkpolicy", func(t *testing.T) {
+		port := intstr.FromInt(8080)
+		expected := &networkingv1.NetworkPolicy{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "NetworkPolicy",
+				APIVersion: "networking.k8s.io/v1",
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{{Port: &port}},
+				}},
+				Egress: []networkingv1.NetworkPolicyEgressRule{{
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}},
+				}},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			},
+		}
+
+		result, err := networkpolicy.GenerateNetworkPolicy(networkpolicy.Conf{
+			GenPodSelectorFunc: func(interfaces.Object) (metav1.LabelSelector, error) {
+				return metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, nil
+			},
+			GenIngressRulesFunc: func(interfaces.Object) ([]networkingv1.NetworkPolicyIngressRule, error) {
+				return []networkingv1.NetworkPolicyIngressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{{Port: &port}},
+				}}, nil
+			},
+			GenEgressRulesFunc: func(interfaces.Object) ([]networkingv1.NetworkPolicyEgressRule, error) {
+				return []networkingv1.NetworkPolicyEgressRule{{
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}},
+				}}, nil
+			},
+			GenPolicyTypesFunc: networkpolicy.PolicyTypes(networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := networkpolicy.MaybeUpdate(&mocks.MockObject{}, &networkingv1.NetworkPolicy{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := networkpolicy.MaybeUpdate(&networkingv1.NetworkPolicy{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := networkpolicy.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare networkpolicies", func(t *testing.T) {
+		t.Run("empty networkpolicies", func(t *testing.T) {
+			result, err := networkpolicy.MaybeUpdate(&networkingv1.NetworkPolicy{}, &networkingv1.NetworkPolicy{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("defaulted fields are ignored", func(t *testing.T) {
+			port := intstr.FromInt(8080)
+			protocol := corev1.ProtocolTCP
+			existingNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{{Port: &port, Protocol: &protocol}},
+				}},
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			}}
+			newNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{{Port: &port}},
+				}},
+			}}
+
+			result, err := networkpolicy.MaybeUpdate(existingNetworkPolicy, newNetworkPolicy)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different pod selector", func(t *testing.T) {
+			existingNetworkPolicy := &networkingv1.NetworkPolicy{}
+			newNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			}}
+
+			result, err := networkpolicy.MaybeUpdate(existingNetworkPolicy, newNetworkPolicy)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingNetworkPolicy, newNetworkPolicy)
+		})
+		t.Run("removed ingress rule", func(t *testing.T) {
+			existingNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{}},
+			}}
+			newNetworkPolicy := &networkingv1.NetworkPolicy{}
+
+			result, err := networkpolicy.MaybeUpdate(existingNetworkPolicy, newNetworkPolicy)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingNetworkPolicy, newNetworkPolicy)
+		})
+		t.Run("removed ingress peer", func(t *testing.T) {
+			a := networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}}
+			b := networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "b"}}}
+			existingNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{From: []networkingv1.NetworkPolicyPeer{a, b}}},
+			}}
+			newNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{From: []networkingv1.NetworkPolicyPeer{a}}},
+			}}
+
+			result, err := networkpolicy.MaybeUpdate(existingNetworkPolicy, newNetworkPolicy)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingNetworkPolicy, newNetworkPolicy)
+		})
+		t.Run("removed all ingress peers", func(t *testing.T) {
+			a := networkingv1.NetworkPolicyPeer{PodSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "a"}}}
+			existingNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{From: []networkingv1.NetworkPolicyPeer{a}}},
+			}}
+			newNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Ingress: []networkingv1.NetworkPolicyIngressRule{{}},
+			}}
+
+			result, err := networkpolicy.MaybeUpdate(existingNetworkPolicy, newNetworkPolicy)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingNetworkPolicy, newNetworkPolicy)
+		})
+		t.Run("removed egress port", func(t *testing.T) {
+			http, https := intstr.FromInt(80), intstr.FromInt(443)
+			protocol := corev1.ProtocolTCP
+			existingNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Egress: []networkingv1.NetworkPolicyEgressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{{Port: &http, Protocol: &protocol}, {Port: &https, Protocol: &protocol}},
+				}},
+			}}
+			newNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Egress: []networkingv1.NetworkPolicyEgressRule{{
+					Ports: []networkingv1.NetworkPolicyPort{{Port: &http}},
+				}},
+			}}
+
+			result, err := networkpolicy.MaybeUpdate(existingNetworkPolicy, newNetworkPolicy)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingNetworkPolicy, newNetworkPolicy)
+		})
+		t.Run("removed egress rule", func(t *testing.T) {
+			existingNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Egress: []networkingv1.NetworkPolicyEgressRule{
+					{To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}}},
+					{To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "172.16.0.0/12"}}}},
+				},
+			}}
+			newNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Egress: []networkingv1.NetworkPolicyEgressRule{
+					{To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}}},
+				},
+			}}
+
+			result, err := networkpolicy.MaybeUpdate(existingNetworkPolicy, newNetworkPolicy)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingNetworkPolicy, newNetworkPolicy)
+		})
+		t.Run("different egress rules", func(t *testing.T) {
+			existingNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Egress: []networkingv1.NetworkPolicyEgressRule{{
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8"}}},
+				}},
+			}}
+			newNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				Egress: []networkingv1.NetworkPolicyEgressRule{{
+					To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "172.16.0.0/12"}}},
+				}},
+			}}
+
+			result, err := networkpolicy.MaybeUpdate(existingNetworkPolicy, newNetworkPolicy)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingNetworkPolicy, newNetworkPolicy)
+		})
+		t.Run("different policy types", func(t *testing.T) {
+			existingNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			}}
+			newNetworkPolicy := &networkingv1.NetworkPolicy{Spec: networkingv1.NetworkPolicySpec{
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+			}}
+
+			result, err := networkpolicy.MaybeUpdate(existingNetworkPolicy, newNetworkPolicy)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingNetworkPolicy, newNetworkPolicy)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := networkpolicy.Create(networkpolicy.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := networkpolicy.Create(networkpolicy.Conf{GenNetworkPolicyFunc: func(networkpolicy.Conf) (*networkingv1.NetworkPolicy, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := networkpolicy.Create(networkpolicy.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create networkpolicy", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := networkpolicy.Create(networkpolicy.Conf{
+			Instance:             i,
+			Reconcile:            r,
+			GenNetworkPolicyFunc: networkpolicy.GenerateDenyAllIngress,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := networkpolicy.Update(networkpolicy.Conf{GenNetworkPolicyFunc: func(networkpolicy.Conf) (*networkingv1.NetworkPolicy, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := networkpolicy.Update(networkpolicy.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("update networkpolicy", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := networkpolicy.Update(networkpolicy.Conf{
+			Name:                "test-existing-networkpolicy",
+			Namespace:           "test",
+			Instance:            i,
+			Reconcile:           r,
+			GenPodSelectorFunc:  networkpolicy.SelectPods(networkpolicy.InstanceLabels),
+			GenIngressRulesFunc: networkpolicy.AllowFromPods(networkpolicy.InstanceLabels),
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := networkpolicy.CreateOrUpdate(networkpolicy.Conf{GenNetworkPolicyFunc: func(networkpolicy.Conf) (*networkingv1.NetworkPolicy, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := networkpolicy.CreateOrUpdate(networkpolicy.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update networkpolicy", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := networkpolicy.CreateOrUpdate(networkpolicy.Conf{
+			Name:                 "test-existing-networkpolicy",
+			Namespace:            "test",
+			Instance:             i,
+			Reconcile:            r,
+			GenNetworkPolicyFunc: networkpolicy.GenerateDenyAllIngress,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := networkpolicy.Delete(networkpolicy.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to delete", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := networkpolicy.Delete(networkpolicy.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("delete networkpolicy", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := networkpolicy.Delete(networkpolicy.Conf{
+			Name:      "test-existing-networkpolicy",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,87 @@
+package networkpolicy
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GenNetworkPolicyFunc defines a function which generates
+// NetworkPolicy.
+type GenNetworkPolicyFunc func(Conf) (*networkingv1.NetworkPolicy, error)
+
+// GenPodSelectorFunc defines a function which generates label
+// selector for the Pods the NetworkPolicy applies to.
+type GenPodSelectorFunc func(interfaces.Object) (metav1.LabelSelector, error)
+
+// GenIngressRulesFunc defines a function which generates slice of
+// NetworkPolicyIngressRule for NetworkPolicy.
+type GenIngressRulesFunc func(interfaces.Object) ([]networkingv1.NetworkPolicyIngressRule, error)
+
+// GenEgressRulesFunc defines a function which generates slice of
+// NetworkPolicyEgressRule for NetworkPolicy.
+type GenEgressRulesFunc func(interfaces.Object) ([]networkingv1.NetworkPolicyEgressRule, error)
+
+// GenPolicyTypesFunc defines a function which generates slice of
+// PolicyType for NetworkPolicy.
+type GenPolicyTypesFunc func(interfaces.Object) ([]networkingv1.PolicyType, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on NetworkPolicy objects.
+type Conf struct {
+	// Instance is the Owner object which manages the NetworkPolicy
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the NetworkPolicy
+	Name string
+	// Namespace of the NetworkPolicy
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on NetworkPolicy before creating it in cluster
+	OwnerReference bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for NetworkPolicy update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the
+	// NetworkPolicy
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the
+	// NetworkPolicy
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the
+	// NetworkPolicy
+	operation.AfterDeleteFunc
+	// GenNetworkPolicyFunc defines a function to generate the
+	// NetworkPolicy object. The package comes with default
+	// networkpolicy generator function which is used by operation
+	// functions. By specifying this field, user can override the
+	// default function with a custom one.
+	GenNetworkPolicyFunc
+	// GenPodSelectorFunc defines a function to generate the Pod
+	// selector for NetworkPolicy. If not specified, the
+	// NetworkPolicy applies to all the Pods in the namespace.
+	GenPodSelectorFunc
+	// GenIngressRulesFunc defines a function to generate ingress
+	// rules for NetworkPolicy
+	GenIngressRulesFunc
+	// GenEgressRulesFunc defines a function to generate egress rules
+	// for NetworkPolicy
+	GenEgressRulesFunc
+	// GenPolicyTypesFunc defines a function to generate policy types
+	// for NetworkPolicy
+	GenPolicyTypesFunc
+}
@@ -0,0 +1,208 @@
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultFieldManager is the field manager used in apply mode if
+// FieldManager is not set in Conf.
+const DefaultFieldManager = "operatorlib"
+
+func apply(ctx context.Context, c Conf) (r reconcile.Result, err error) {
+	log := newOperationLog(ctx, c, ActionUpdate)
+	defer func() { log.done(err) }()
+
+	cl := c.Reconcile.GetClient()
+
+	r, vetoed, err := runBeforeHooks(ctx, c, c.Object, c.BeforeUpdateHooks)
+	if err != nil {
+		return r, errors.Wrap(err, "failed to run BeforeUpdate hook")
+	}
+	if vetoed {
+		log.vetoed()
+		return r, nil
+	}
+
+	if c.OwnerReference {
+		err = controllerutil.SetControllerReference(c.Instance, c.Object, c.Reconcile.GetScheme())
+		if err != nil {
+			return reconcile.Result{}, errors.Wrapf(err, "failed to set owner reference on the object")
+		}
+	}
+
+	fieldManager := c.FieldManager
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+
+	// Apply does not tell what it changed, so the existing object is
+	// fetched to compute the plan entry.
+	var existing interfaces.Object
+	if c.Plan != nil {
+		existing, err = fetch(ctx, c)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if c.DryRun == ClientDryRun {
+		return r, recordApply(c, existing, c.Object)
+	}
+
+	opts := []client.PatchOption{client.FieldOwner(fieldManager)}
+	if c.ForceConflicts {
+		opts = append(opts, client.ForceOwnership)
+	}
+	if c.DryRun == ServerDryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+
+	// The Object is changed by the response, so keep a copy of it
+	// for the plan.
+	var object runtime.Object
+	if c.Plan != nil {
+		object = c.Object.DeepCopyObject()
+	}
+	created := false
+
+	cctx, cancel := CallContext(ctx, c.Timeout)
+	err = cl.Patch(cctx, c.Object, client.Apply, opts...)
+	cancel()
+	// Apply creates the object if it does not exist, so not found
+	// error can only come from a client which looks up the object
+	// before checking the patch type, like the fake client.
+	if c.EmulateApply && (kerrors.IsNotFound(err) || isApplyUnsupported(err)) {
+		created, err = mergeApply(ctx, c, fieldManager)
+	} else if err != nil {
+		recordFailure(c, eventReasons(c).UpdateFailed, "apply", err)
+	} else {
+		recordSuccess(c, eventReasons(c).Updated, "Applied")
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to apply the object in cluster")
+	}
+
+	if created {
+		log.action = ActionCreate
+	}
+
+	err = recordApply(c, existing, object)
+	if err != nil || c.DryRun != NoDryRun {
+		return r, err
+	}
+
+	if created {
+		ar, err := runAfterHooks(ctx, c, HookWithContextFunc(c.AfterCreateWithContextFunc), HookFunc(c.AfterCreateFunc), c.AfterCreateHooks)
+		r = MergeResults(r, ar)
+		if err != nil {
+			return r, errors.Wrap(err, "failed to run AfterCreate hook")
+		}
+
+		return r, nil
+	}
+
+	ar, err := runAfterHooks(ctx, c, HookWithContextFunc(c.AfterUpdateWithContextFunc), HookFunc(c.AfterUpdateFunc), c.AfterUpdateHooks)
+	r = MergeResults(r, ar)
+	if err != nil {
+		return r, errors.Wrap(err, "failed to run AfterUpdate hook")
+	}
+
+	return r, nil
+}
+
+// mergeApply emulates apply with a merge patch of the Object. It is
+// used when the apply patches are not supported and EmulateApply is
+// set in Conf. Fields not set in
+// the Object are left untouched but, unlike apply, the fields removed
+// from the Object are not removed from the in-cluster object. It
+// reports if the Object is created as it did not exist.
+func mergeApply(ctx context.Context, c Conf, fieldManager string) (bool, error) {
+	cl := c.Reconcile.GetClient()
+
+	data, err := json.Marshal(c.Object)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to encode the object")
+	}
+
+	var patchOpts []client.PatchOption
+	createOpts := []client.CreateOption{client.FieldOwner(fieldManager)}
+	if c.DryRun == ServerDryRun {
+		patchOpts = append(patchOpts, client.DryRunAll)
+		createOpts = append(createOpts, client.DryRunAll)
+	}
+
+	cctx, cancel := CallContext(ctx, c.Timeout)
+	err = cl.Patch(cctx, c.Object, client.ConstantPatch(types.MergePatchType, data), patchOpts...)
+	cancel()
+	if kerrors.IsNotFound(err) {
+		cctx, cancel := CallContext(ctx, c.Timeout)
+		err = cl.Create(cctx, c.Object, createOpts...)
+		cancel()
+		if err != nil {
+			recordFailure(c, eventReasons(c).CreateFailed, "create", err)
+			return false, errors.Wrap(err, "failed to create the object in cluster")
+		}
+		recordSuccess(c, eventReasons(c).Created, "Created")
+
+		return true, nil
+	}
+	if err != nil {
+		recordFailure(c, eventReasons(c).UpdateFailed, "update", err)
+		return false, errors.Wrap(err, "failed to patch the object in cluster")
+	}
+	recordSuccess(c, eventReasons(c).Updated, "Updated")
+
+	return false, nil
+}
+
+// recordApply adds the plan entry for applying the object over the
+// existing one, which is nil if it does not exist.
+func recordApply(c Conf, existing interfaces.Object, object runtime.Object) error {
+	if c.Plan == nil {
+		return nil
+	}
+
+	if existing == nil {
+		return recordChange(c, ActionCreate, nil, object)
+	}
+
+	modified, err := merged(existing, object)
+	if err != nil {
+		return err
+	}
+
+	diff, err := Diff(existing, modified)
+	if err != nil {
+		return errors.Wrap(err, "failed to compute the diff of the object")
+	}
+	if len(diff) == 0 {
+		record(c, ActionNoop, nil)
+		return nil
+	}
+
+	record(c, ActionUpdate, diff)
+	return nil
+}
+
+// isApplyUnsupported reports if the error is returned because apply
+// patches are not supported. API Servers without server-side apply
+// reject the patch with Unsupported Media Type status while the fake
+// client returns a plain error.
+func isApplyUnsupported(err error) bool {
+	if err == nil {
+		return false
+	}
+	return kerrors.IsUnsupportedMediaType(err) || strings.Contains(err.Error(), "PatchType is not supported")
+}
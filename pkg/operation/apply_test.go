@@ -0,0 +1,133 @@
+package operation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// patchClient records the patch requests and fails them with err, if
+// set. Otherwise it pretends that the patch succeeded.
+type patchClient struct {
+	client.Client
+	err       error
+	patchType types.PatchType
+	opts      client.PatchOptions
+}
+
+func (c *patchClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	c.patchType = patch.Type()
+	c.opts = client.PatchOptions{}
+	c.opts.ApplyOptions(opts)
+	return c.err
+}
+
+func TestApply(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	applySetup := func(err error) (*mocks.MockObject, *mocks.MockReconcile, *patchClient) {
+		i, r := mockSetup(controller)
+		c := &patchClient{Client: r.GetClient(), err: err}
+		wrapped := mocks.NewMockReconcile(controller)
+		wrapped.EXPECT().GetClient().Return(c).AnyTimes()
+		wrapped.EXPECT().GetScheme().Return(r.GetScheme()).AnyTimes()
+		return i, wrapped, c
+	}
+	object := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+			Data:       map[string]string{"key1": "new-value1"},
+		}
+	}
+
+	t.Run("apply options", func(t *testing.T) {
+		i, r, c := applySetup(nil)
+
+		_, err := operation.CreateOrUpdate(operation.Conf{
+			Instance:       i,
+			Reconcile:      r,
+			Object:         object(),
+			Apply:          true,
+			FieldManager:   "test",
+			ForceConflicts: true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, types.ApplyPatchType, c.patchType)
+		assert.Equal(t, "test", c.opts.FieldManager)
+		if assert.NotNil(t, c.opts.Force) {
+			assert.True(t, *c.opts.Force)
+		}
+		assert.Empty(t, c.opts.DryRun)
+	})
+	t.Run("default apply options", func(t *testing.T) {
+		i, r, c := applySetup(nil)
+
+		_, err := operation.CreateOrUpdate(operation.Conf{Instance: i, Reconcile: r, Object: object(), Apply: true})
+		assert.NoError(t, err)
+		assert.Equal(t, operation.DefaultFieldManager, c.opts.FieldManager)
+		assert.Nil(t, c.opts.Force)
+	})
+	t.Run("server dry-run apply options", func(t *testing.T) {
+		i, r, c := applySetup(nil)
+
+		_, err := operation.CreateOrUpdate(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    object(),
+			Apply:     true,
+			DryRun:    operation.ServerDryRun,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{metav1.DryRunAll}, c.opts.DryRun)
+	})
+	t.Run("apply calls after update hooks", func(t *testing.T) {
+		i, r, _ := applySetup(nil)
+		var called []string
+		hook := func(name string) operation.HookFunc {
+			return func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				called = append(called, name)
+				return reconcile.Result{}, nil
+			}
+		}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{
+			Instance:        i,
+			Reconcile:       r,
+			Object:          object(),
+			Apply:           true,
+			AfterCreateFunc: operation.AfterCreateFunc(hook("create")),
+			AfterUpdateFunc: operation.AfterUpdateFunc(hook("update")),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"update"}, called)
+	})
+	t.Run("apply patches are not supported", func(t *testing.T) {
+		unsupported := &kerrors.StatusError{ErrStatus: metav1.Status{
+			Status: metav1.StatusFailure,
+			Code:   415,
+			Reason: metav1.StatusReasonUnsupportedMediaType,
+		}}
+		i, r, c := applySetup(unsupported)
+
+		_, err := operation.CreateOrUpdate(operation.Conf{Instance: i, Reconcile: r, Object: object(), Apply: true})
+		assert.Error(t, err)
+		assert.True(t, kerrors.IsUnsupportedMediaType(errors.Cause(err)))
+		assert.Equal(t, types.ApplyPatchType, c.patchType)
+	})
+}
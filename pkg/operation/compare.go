@@ -0,0 +1,58 @@
+package operation
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// DeepDerivative is like equality.Semantic.DeepDerivative, which
+// ignores the fields not set in the new value so that the fields
+// defaulted by API Server do not cause updates, except that the lists
+// must also have the same length. DeepDerivative alone passes if the
+// new list is a prefix of the existing one, so removing a container,
+// environment variable, volume or port would go unnoticed.
+func DeepDerivative(new, existing interface{}) bool {
+	if !equality.Semantic.DeepDerivative(new, existing) {
+		return false
+	}
+	return sameLengths(reflect.ValueOf(new), reflect.ValueOf(existing))
+}
+
+// sameLengths reports if all the lists in the values passed, which
+// are of the same type, have the same length. Only the exported
+// fields of structs are walked into, as the unexported ones are
+// compared using custom equality functions, like for Quantity.
+func sameLengths(n, e reflect.Value) bool {
+	if !n.IsValid() || !e.IsValid() || n.Type() != e.Type() {
+		return true
+	}
+
+	switch n.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if n.IsNil() || e.IsNil() {
+			return true
+		}
+		return sameLengths(n.Elem(), e.Elem())
+	case reflect.Struct:
+		for i := 0; i < n.NumField(); i++ {
+			if n.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if !sameLengths(n.Field(i), e.Field(i)) {
+				return false
+			}
+		}
+	case reflect.Slice:
+		if n.Len() != e.Len() {
+			return false
+		}
+		for i := 0; i < n.Len(); i++ {
+			if !sameLengths(n.Index(i), e.Index(i)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
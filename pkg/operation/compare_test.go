@@ -0,0 +1,68 @@
+package operation_test
+
+import (
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestDeepDerivative(t *testing.T) {
+	container := func(env ...corev1.EnvVar) corev1.Container {
+		return corev1.Container{Name: "test", Image: "test:v1", Env: env}
+	}
+	foo := corev1.EnvVar{Name: "FOO", Value: "foo"}
+	bar := corev1.EnvVar{Name: "BAR", Value: "bar"}
+
+	tests := []struct {
+		name     string
+		new      corev1.PodSpec
+		existing corev1.PodSpec
+		expected bool
+	}{
+		{
+			name:     "defaulted fields are ignored",
+			new:      corev1.PodSpec{Containers: []corev1.Container{container(foo)}},
+			existing: corev1.PodSpec{RestartPolicy: corev1.RestartPolicyAlways, Containers: []corev1.Container{container(foo)}},
+			expected: true,
+		},
+		{
+			name:     "added container",
+			new:      corev1.PodSpec{Containers: []corev1.Container{container(), container()}},
+			existing: corev1.PodSpec{Containers: []corev1.Container{container()}},
+		},
+		{
+			name:     "removed container",
+			new:      corev1.PodSpec{Containers: []corev1.Container{container()}},
+			existing: corev1.PodSpec{Containers: []corev1.Container{container(), container()}},
+		},
+		{
+			name:     "removed all init containers",
+			existing: corev1.PodSpec{InitContainers: []corev1.Container{container()}},
+		},
+		{
+			name:     "removed environment variable",
+			new:      corev1.PodSpec{Containers: []corev1.Container{container(foo)}},
+			existing: corev1.PodSpec{Containers: []corev1.Container{container(foo, bar)}},
+		},
+		{
+			name: "equal quantities",
+			new: corev1.PodSpec{Containers: []corev1.Container{{Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1000m")},
+			}}}},
+			existing: corev1.PodSpec{Containers: []corev1.Container{{Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+			}}}},
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, operation.DeepDerivative(test.new, test.existing))
+		})
+	}
+}
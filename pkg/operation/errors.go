@@ -0,0 +1,69 @@
+package operation
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// ImmutableFieldError is returned by MaybeUpdate functions when a
+// field which cannot be updated in the cluster is different in the
+// generated object. API Server rejects such updates anyway, so this
+// error lets the caller detect the condition and decide what to do,
+// for instance, delete and recreate the object.
+type ImmutableFieldError struct {
+	// Kind of the object which has the immutable field
+	Kind string
+	// Field is the path of the immutable field in the object
+	Field string
+}
+
+// Error implements the error interface.
+func (e *ImmutableFieldError) Error() string {
+	return fmt.Sprintf("%s field of %s object is different, however, it is immutable field which cannot be changed", e.Field, e.Kind)
+}
+
+// NewImmutableFieldError returns ImmutableFieldError for the field of
+// the Kind passed.
+func NewImmutableFieldError(kind, field string) error {
+	return &ImmutableFieldError{Kind: kind, Field: field}
+}
+
+// IsImmutableFieldError checks if the cause of the error passed is
+// ImmutableFieldError.
+func IsImmutableFieldError(err error) bool {
+	_, ok := errors.Cause(err).(*ImmutableFieldError)
+	return ok
+}
+
+// ConflictError is returned by Update and CreateOrUpdate when the
+// object is changed in the cluster while being updated and the
+// conflict remains after the retries, if any. Conflicts are expected
+// when reconciles overlap, so callers can detect this error and
+// requeue without treating it as a failure.
+type ConflictError struct {
+	// Name of the object which could not be updated
+	Name string
+	// Namespace of the object which could not be updated
+	Namespace string
+	// Err is the conflict error returned by API Server
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("%s object in %s namespace is modified in cluster while updating it: %v", e.Name, e.Namespace, e.Err)
+}
+
+// NewConflictError returns ConflictError for the object with name and
+// namespace passed.
+func NewConflictError(name, namespace string, err error) error {
+	return &ConflictError{Name: name, Namespace: namespace, Err: err}
+}
+
+// IsConflictError checks if the cause of the error passed is
+// ConflictError.
+func IsConflictError(err error) bool {
+	_, ok := errors.Cause(err).(*ConflictError)
+	return ok
+}
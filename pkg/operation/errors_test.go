@@ -0,0 +1,43 @@
+package operation_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsImmutableFieldError(t *testing.T) {
+	t.Run("immutable field error", func(t *testing.T) {
+		assert.True(t, operation.IsImmutableFieldError(operation.NewImmutableFieldError("Service", "spec.type")))
+	})
+	t.Run("wrapped immutable field error", func(t *testing.T) {
+		err := pkgerrors.Wrap(operation.NewImmutableFieldError("Service", "spec.type"), "failed to update the object")
+		assert.True(t, operation.IsImmutableFieldError(err))
+	})
+	t.Run("other error", func(t *testing.T) {
+		assert.False(t, operation.IsImmutableFieldError(errors.New("test error")))
+	})
+	t.Run("nil error", func(t *testing.T) {
+		assert.False(t, operation.IsImmutableFieldError(nil))
+	})
+}
+
+func TestIsConflictError(t *testing.T) {
+	t.Run("conflict error", func(t *testing.T) {
+		assert.True(t, operation.IsConflictError(operation.NewConflictError("test", "test", errors.New("test error"))))
+	})
+	t.Run("wrapped conflict error", func(t *testing.T) {
+		err := pkgerrors.Wrap(operation.NewConflictError("test", "test", errors.New("test error")), "failed to update the object")
+		assert.True(t, operation.IsConflictError(err))
+	})
+	t.Run("other error", func(t *testing.T) {
+		assert.False(t, operation.IsConflictError(errors.New("test error")))
+	})
+	t.Run("nil error", func(t *testing.T) {
+		assert.False(t, operation.IsConflictError(nil))
+	})
+}
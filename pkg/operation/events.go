@@ -0,0 +1,99 @@
+package operation
+
+import (
+	"fmt"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// EventReasons are the reasons of the Events recorded by the operation
+// functions. Empty reasons are replaced with the ones from
+// DefaultEventReasons.
+type EventReasons struct {
+	// Created is the reason of the Normal Event recorded after
+	// creating the Object.
+	Created string
+	// Updated is the reason of the Normal Event recorded after
+	// updating the Object.
+	Updated string
+	// Deleted is the reason of the Normal Event recorded after
+	// deleting the Object.
+	Deleted string
+	// CreateFailed is the reason of the Warning Event recorded when
+	// the Object cannot be created.
+	CreateFailed string
+	// UpdateFailed is the reason of the Warning Event recorded when
+	// the Object cannot be updated.
+	UpdateFailed string
+	// DeleteFailed is the reason of the Warning Event recorded when
+	// the Object cannot be deleted.
+	DeleteFailed string
+}
+
+// DefaultEventReasons are the reasons used for the Events unless
+// overridden by EventReasons in Conf.
+var DefaultEventReasons = EventReasons{
+	Created:      "Created",
+	Updated:      "Updated",
+	Deleted:      "Deleted",
+	CreateFailed: "CreateFailed",
+	UpdateFailed: "UpdateFailed",
+	DeleteFailed: "DeleteFailed",
+}
+
+// eventReasons returns EventReasons from Conf with the empty reasons
+// replaced with the default ones.
+func eventReasons(c Conf) EventReasons {
+	r, d := c.EventReasons, DefaultEventReasons
+	return EventReasons{
+		Created:      orDefault(r.Created, d.Created),
+		Updated:      orDefault(r.Updated, d.Updated),
+		Deleted:      orDefault(r.Deleted, d.Deleted),
+		CreateFailed: orDefault(r.CreateFailed, d.CreateFailed),
+		UpdateFailed: orDefault(r.UpdateFailed, d.UpdateFailed),
+		DeleteFailed: orDefault(r.DeleteFailed, d.DeleteFailed),
+	}
+}
+
+func orDefault(reason, def string) string {
+	if reason == "" {
+		return def
+	}
+	return reason
+}
+
+// recordSuccess records the Normal Event for the operation done on
+// the Object, like "Created ConfigMap foo". done is the past tense of
+// the operation.
+func recordSuccess(c Conf, reason, done string) {
+	recordEvent(c, corev1.EventTypeNormal, reason, func(object string) string {
+		return fmt.Sprintf("%s %s", done, object)
+	})
+}
+
+// recordFailure records the Warning Event for the operation which
+// failed on the Object, like "Failed to create ConfigMap foo: ...".
+func recordFailure(c Conf, reason, operation string, err error) {
+	recordEvent(c, corev1.EventTypeWarning, reason, func(object string) string {
+		return fmt.Sprintf("Failed to %s %s: %v", operation, object, err)
+	})
+}
+
+// recordEvent records the Event against the Instance if the Reconcile
+// struct implements interfaces.EventRecorder. The message is built
+// from the kind and name of the Object. Nothing is recorded in
+// dry-run mode since nothing is changed.
+func recordEvent(c Conf, eventType, reason string, message func(object string) string) {
+	if c.DryRun != NoDryRun || c.Instance == nil || c.Object == nil {
+		return
+	}
+
+	r, ok := c.Reconcile.(interfaces.EventRecorder)
+	if !ok || r.GetEventRecorder() == nil {
+		return
+	}
+
+	r.GetEventRecorder().Event(c.Instance, eventType, reason, message(fmt.Sprintf("%s %s", gvkFor(c).Kind, c.Object.GetName())))
+}
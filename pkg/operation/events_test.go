@@ -0,0 +1,235 @@
+package operation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// failingClient fails all the create and delete requests.
+type failingClient struct {
+	client.Client
+}
+
+func (failingClient) Create(context.Context, runtime.Object, ...client.CreateOption) error {
+	return errors.New("test error")
+}
+
+func (failingClient) Delete(context.Context, runtime.Object, ...client.DeleteOption) error {
+	return errors.New("test error")
+}
+
+func TestEvents(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	setup := func() (*mocks.MockObject, *mocks.RecorderReconcile) {
+		i, r := mockSetup(controller)
+		return i, mocks.NewRecorderReconcile(r, 10)
+	}
+	wrappedSetup := func(wrap func(client.Client) client.Client) (*mocks.MockObject, *mocks.RecorderReconcile) {
+		i, r := mockSetup(controller)
+		wrapped := mocks.NewMockReconcile(controller)
+		wrapped.EXPECT().GetClient().Return(wrap(r.GetClient())).AnyTimes()
+		wrapped.EXPECT().GetScheme().Return(r.GetScheme()).AnyTimes()
+		return i, mocks.NewRecorderReconcile(wrapped, 10)
+	}
+	failingSetup := func() (*mocks.MockObject, *mocks.RecorderReconcile) {
+		return wrappedSetup(func(c client.Client) client.Client { return failingClient{Client: c} })
+	}
+	updateData := func(existing interfaces.Object, new interfaces.Object) (bool, error) {
+		e := existing.(*corev1.ConfigMap)
+		n := new.(*corev1.ConfigMap)
+		if e.Data["key1"] == n.Data["key1"] {
+			return false, nil
+		}
+		e.Data["key1"] = n.Data["key1"]
+		return true, nil
+	}
+
+	t.Run("create configmap", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+
+		_, err := operation.Create(operation.Conf{Instance: i, Reconcile: r, Object: object})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Normal Created Created ConfigMap test-configmap"}, r.Events())
+	})
+	t.Run("create configmap fails", func(t *testing.T) {
+		i, r := failingSetup()
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+
+		_, err := operation.Create(operation.Conf{Instance: i, Reconcile: r, Object: object})
+		assert.Error(t, err)
+		assert.Equal(t, []string{"Warning CreateFailed Failed to create ConfigMap test-configmap: test error"}, r.Events())
+	})
+	t.Run("create existing configmap fails", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}}
+
+		_, err := operation.Create(operation.Conf{Instance: i, Reconcile: r, Object: object})
+		assert.Error(t, err)
+		e := r.Events()
+		if assert.Len(t, e, 1) {
+			assert.Contains(t, e[0], "Warning CreateFailed Failed to create ConfigMap test-existing-configmap: ")
+		}
+	})
+	t.Run("create or update existing configmap", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+			Data:       map[string]string{"key1": "new"},
+		}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{Instance: i, Reconcile: r, Object: object, MaybeUpdateFunc: updateData})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Normal Updated Updated ConfigMap test-existing-configmap"}, r.Events())
+	})
+	t.Run("update up-to-date configmap", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+			Data:       map[string]string{"key1": "value1"},
+		}
+
+		_, err := operation.Update(operation.Conf{Instance: i, Reconcile: r, Object: object, MaybeUpdateFunc: updateData})
+		assert.NoError(t, err)
+		assert.Empty(t, r.Events())
+	})
+	t.Run("update configmap which does not exist", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+
+		_, err := operation.Update(operation.Conf{Instance: i, Reconcile: r, Object: object, MaybeUpdateFunc: updateData})
+		assert.Error(t, err)
+		e := r.Events()
+		if assert.Len(t, e, 1) {
+			assert.Contains(t, e[0], "Warning UpdateFailed Failed to update ConfigMap test-configmap: ")
+		}
+	})
+	t.Run("update configmap with conflict", func(t *testing.T) {
+		i, r := wrappedSetup(func(c client.Client) client.Client { return &conflictClient{Client: c, conflicts: 1} })
+		object := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+			Data:       map[string]string{"key1": "new"},
+		}
+
+		_, err := operation.Update(operation.Conf{Instance: i, Reconcile: r, Object: object, MaybeUpdateFunc: updateData})
+		assert.True(t, operation.IsConflictError(err))
+		assert.Empty(t, r.Events())
+	})
+	t.Run("update configmap with immutable field", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}}
+		immutable := func(interfaces.Object, interfaces.Object) (bool, error) {
+			return false, operation.NewImmutableFieldError("ConfigMap", "data")
+		}
+
+		_, err := operation.Update(operation.Conf{Instance: i, Reconcile: r, Object: object, MaybeUpdateFunc: immutable})
+		assert.True(t, operation.IsImmutableFieldError(err))
+		assert.Empty(t, r.Events())
+	})
+	t.Run("apply configmap", func(t *testing.T) {
+		i, r := wrappedSetup(func(c client.Client) client.Client { return &patchClient{Client: c} })
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{Instance: i, Reconcile: r, Object: object, Apply: true})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Normal Updated Applied ConfigMap test-existing-configmap"}, r.Events())
+	})
+	t.Run("apply configmap fails", func(t *testing.T) {
+		i, r := wrappedSetup(func(c client.Client) client.Client { return &patchClient{Client: c, err: errors.New("test error")} })
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{Instance: i, Reconcile: r, Object: object, Apply: true})
+		assert.Error(t, err)
+		assert.Equal(t, []string{"Warning UpdateFailed Failed to apply ConfigMap test-existing-configmap: test error"}, r.Events())
+	})
+	t.Run("emulated apply creates configmap", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"},
+		}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{Instance: i, Reconcile: r, Object: object, Apply: true, EmulateApply: true})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Normal Created Created ConfigMap test-configmap"}, r.Events())
+	})
+	t.Run("emulated apply updates configmap", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+			Data:       map[string]string{"key1": "new"},
+		}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{Instance: i, Reconcile: r, Object: object, Apply: true, EmulateApply: true})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Normal Updated Updated ConfigMap test-existing-configmap"}, r.Events())
+	})
+	t.Run("delete configmap", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}}
+
+		_, err := operation.Delete(operation.Conf{Instance: i, Reconcile: r, Object: object})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"Normal Deleted Deleted ConfigMap test-existing-configmap"}, r.Events())
+	})
+	t.Run("delete configmap fails", func(t *testing.T) {
+		i, r := failingSetup()
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}}
+
+		_, err := operation.Delete(operation.Conf{Instance: i, Reconcile: r, Object: object})
+		assert.Error(t, err)
+		assert.Equal(t, []string{"Warning DeleteFailed Failed to delete ConfigMap test-existing-configmap: test error"}, r.Events())
+	})
+	t.Run("delete configmap which does not exist", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+
+		_, err := operation.Delete(operation.Conf{Instance: i, Reconcile: r, Object: object})
+		assert.NoError(t, err)
+		assert.Empty(t, r.Events())
+	})
+	t.Run("custom reasons", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+		reasons := operation.EventReasons{Created: "ConfigMapCreated", Deleted: "ConfigMapDeleted"}
+
+		_, err := operation.Create(operation.Conf{Instance: i, Reconcile: r, Object: object, EventReasons: reasons})
+		assert.NoError(t, err)
+		_, err = operation.Delete(operation.Conf{Instance: i, Reconcile: r, Object: object, EventReasons: reasons})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"Normal ConfigMapCreated Created ConfigMap test-configmap",
+			"Normal ConfigMapDeleted Deleted ConfigMap test-configmap",
+		}, r.Events())
+	})
+	t.Run("dry-run", func(t *testing.T) {
+		i, r := setup()
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+
+		_, err := operation.Create(operation.Conf{Instance: i, Reconcile: r, Object: object, DryRun: operation.ServerDryRun})
+		assert.NoError(t, err)
+		assert.Empty(t, r.Events())
+	})
+	t.Run("reconcile without recorder", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+
+		_, err := operation.Create(operation.Conf{Instance: i, Reconcile: r, Object: object})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,86 @@
+package operation
+
+import (
+	"context"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ErrVeto can be returned by Before hooks to skip the operation. The
+// operation function returns without error and the rest of the hooks
+// are not called. Hooks can also return an error wrapping ErrVeto.
+var ErrVeto = errors.New("operation is vetoed by hook")
+
+// BeforeHookFunc is the function type which is used by the hooks
+// called before an operation. Along with the owner object and
+// reconcile struct, it receives the object on which the operation is
+// to be performed, which it can mutate. The operation can be skipped
+// by returning ErrVeto. The results returned are merged into the
+// result of the operation, even if it fails or is a dry-run.
+type BeforeHookFunc func(ctx context.Context, instance interfaces.Object, reconcile interfaces.Reconcile, object interfaces.Object) (reconcile.Result, error)
+
+// DiffHookFunc is the function type of the hook called with the
+// fields changed by Update. It is called only if the Object is
+// updated, or would be in dry-run mode, which helps in finding out
+// why an object is updated in every reconcile.
+type DiffHookFunc func(ctx context.Context, instance interfaces.Object, object interfaces.Object, diff []FieldDiff)
+
+// MergeResults merges the reconcile results passed. The merged result
+// asks for requeue if any of the results does and uses the shortest
+// of the non-zero RequeueAfter.
+func MergeResults(results ...reconcile.Result) reconcile.Result {
+	var merged reconcile.Result
+	for _, r := range results {
+		merged.Requeue = merged.Requeue || r.Requeue
+		if r.RequeueAfter > 0 && (merged.RequeueAfter == 0 || r.RequeueAfter < merged.RequeueAfter) {
+			merged.RequeueAfter = r.RequeueAfter
+		}
+	}
+	return merged
+}
+
+// runBeforeHooks calls the hooks passed in order with the object. It
+// stops at the first hook which fails or vetoes the operation and
+// reports if the operation is vetoed.
+func runBeforeHooks(ctx context.Context, c Conf, object interfaces.Object, hooks []BeforeHookFunc) (r reconcile.Result, vetoed bool, err error) {
+	for _, hook := range hooks {
+		var hr reconcile.Result
+		hr, err = hook(ctx, c.Instance, c.Reconcile, object)
+		r = MergeResults(r, hr)
+		if errors.Cause(err) == ErrVeto {
+			return r, true, nil
+		}
+		if err != nil {
+			return r, false, err
+		}
+	}
+	return r, false, nil
+}
+
+// runAfterHooks calls the context-aware hook if set, or else the plain
+// one if that is set, followed by the hooks in the list in order. It
+// stops at the first hook which fails.
+func runAfterHooks(ctx context.Context, c Conf, hookWithContext HookWithContextFunc, hook HookFunc, hooks []HookWithContextFunc) (r reconcile.Result, err error) {
+	if hookWithContext != nil {
+		hooks = append([]HookWithContextFunc{hookWithContext}, hooks...)
+	} else if hook != nil {
+		r, err = hook(c.Instance, c.Reconcile)
+		if err != nil {
+			return r, err
+		}
+	}
+
+	for _, h := range hooks {
+		var hr reconcile.Result
+		hr, err = h(ctx, c.Instance, c.Reconcile)
+		r = MergeResults(r, hr)
+		if err != nil {
+			return r, err
+		}
+	}
+
+	return r, nil
+}
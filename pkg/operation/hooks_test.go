@@ -0,0 +1,269 @@
+package operation_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/golang/mock/gomock"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestMergeResults(t *testing.T) {
+	t.Run("no results", func(t *testing.T) {
+		assert.Equal(t, reconcile.Result{}, operation.MergeResults())
+	})
+	t.Run("requeue", func(t *testing.T) {
+		assert.Equal(t, reconcile.Result{Requeue: true}, operation.MergeResults(reconcile.Result{}, reconcile.Result{Requeue: true}))
+	})
+	t.Run("shortest requeue after", func(t *testing.T) {
+		result := operation.MergeResults(
+			reconcile.Result{RequeueAfter: time.Minute},
+			reconcile.Result{},
+			reconcile.Result{RequeueAfter: time.Second},
+			reconcile.Result{Requeue: true, RequeueAfter: time.Hour},
+		)
+		assert.Equal(t, reconcile.Result{Requeue: true, RequeueAfter: time.Second}, result)
+	})
+}
+
+func TestHooks(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("before create hook mutates the object", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+
+		_, err := operation.Create(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}},
+			BeforeCreateHooks: []operation.BeforeHookFunc{
+				func(_ context.Context, _ interfaces.Object, _ interfaces.Reconcile, o interfaces.Object) (reconcile.Result, error) {
+					o.SetLabels(map[string]string{"hook": "test"})
+					return reconcile.Result{}, nil
+				},
+			},
+		})
+		assert.NoError(t, err)
+
+		createdObject := &corev1.ConfigMap{}
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-configmap", Namespace: "test"}, createdObject)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"hook": "test"}, createdObject.GetLabels())
+	})
+	t.Run("before create hook vetoes the creation", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+		called := false
+
+		result, err := operation.Create(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}},
+			BeforeCreateHooks: []operation.BeforeHookFunc{
+				func(context.Context, interfaces.Object, interfaces.Reconcile, interfaces.Object) (reconcile.Result, error) {
+					return reconcile.Result{RequeueAfter: time.Minute}, pkgerrors.Wrap(operation.ErrVeto, "not yet")
+				},
+				func(context.Context, interfaces.Object, interfaces.Reconcile, interfaces.Object) (reconcile.Result, error) {
+					called = true
+					return reconcile.Result{}, nil
+				},
+			},
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				called = true
+				return reconcile.Result{}, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{RequeueAfter: time.Minute}, result)
+		assert.False(t, called)
+
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-configmap", Namespace: "test"}, &corev1.ConfigMap{})
+		assert.Error(t, err)
+	})
+	t.Run("before create hook is not called for existing object", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+		called := false
+
+		_, err := operation.CreateOrUpdate(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object: &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+				Data:       map[string]string{"key1": "new-value1"},
+			},
+			MaybeUpdateFunc: func(existing interfaces.Object, new interfaces.Object) (bool, error) {
+				existing.(*corev1.ConfigMap).Data = new.(*corev1.ConfigMap).Data
+				return true, nil
+			},
+			BeforeCreateHooks: []operation.BeforeHookFunc{
+				func(_ context.Context, _ interfaces.Object, _ interfaces.Reconcile, o interfaces.Object) (reconcile.Result, error) {
+					called = true
+					o.SetLabels(map[string]string{"hook": "test"})
+					return reconcile.Result{}, operation.ErrVeto
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.False(t, called)
+
+		updatedObject := &corev1.ConfigMap{}
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, updatedObject)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"key1": "new-value1"}, updatedObject.Data)
+		assert.Empty(t, updatedObject.GetLabels())
+	})
+	t.Run("before create hook vetoes create or update of new object", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+
+		_, err := operation.CreateOrUpdate(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}},
+			BeforeCreateHooks: []operation.BeforeHookFunc{
+				func(context.Context, interfaces.Object, interfaces.Reconcile, interfaces.Object) (reconcile.Result, error) {
+					return reconcile.Result{}, operation.ErrVeto
+				},
+			},
+		})
+		assert.NoError(t, err)
+
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-configmap", Namespace: "test"}, &corev1.ConfigMap{})
+		assert.Error(t, err)
+	})
+	t.Run("before update hook fails", func(t *testing.T) {
+		i, r := mockSetup(controller)
+
+		_, err := operation.Update(operation.Conf{
+			Instance:        i,
+			Reconcile:       r,
+			Object:          &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			MaybeUpdateFunc: func(interfaces.Object, interfaces.Object) (bool, error) { return true, nil },
+			BeforeUpdateHooks: []operation.BeforeHookFunc{
+				func(context.Context, interfaces.Object, interfaces.Reconcile, interfaces.Object) (reconcile.Result, error) {
+					return reconcile.Result{}, errors.New("test error")
+				},
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("before update hook result is kept in dry-run", func(t *testing.T) {
+		i, r := mockSetup(controller)
+
+		result, err := operation.Update(operation.Conf{
+			Instance:        i,
+			Reconcile:       r,
+			Object:          &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			DryRun:          operation.ClientDryRun,
+			MaybeUpdateFunc: func(interfaces.Object, interfaces.Object) (bool, error) { return true, nil },
+			BeforeUpdateHooks: []operation.BeforeHookFunc{
+				func(context.Context, interfaces.Object, interfaces.Reconcile, interfaces.Object) (reconcile.Result, error) {
+					return reconcile.Result{RequeueAfter: time.Minute}, nil
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{RequeueAfter: time.Minute}, result)
+	})
+	t.Run("before update hook result is kept on failure", func(t *testing.T) {
+		i, r := mockSetup(controller)
+
+		result, err := operation.Update(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			MaybeUpdateFunc: func(interfaces.Object, interfaces.Object) (bool, error) {
+				return false, errors.New("test error")
+			},
+			BeforeUpdateHooks: []operation.BeforeHookFunc{
+				func(context.Context, interfaces.Object, interfaces.Reconcile, interfaces.Object) (reconcile.Result, error) {
+					return reconcile.Result{RequeueAfter: time.Minute}, nil
+				},
+			},
+		})
+		assert.Error(t, err)
+		assert.Equal(t, reconcile.Result{RequeueAfter: time.Minute}, result)
+	})
+	t.Run("before delete hook vetoes the deletion", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+
+		_, err := operation.Delete(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			BeforeDeleteHooks: []operation.BeforeHookFunc{
+				func(context.Context, interfaces.Object, interfaces.Reconcile, interfaces.Object) (reconcile.Result, error) {
+					return reconcile.Result{}, operation.ErrVeto
+				},
+			},
+		})
+		assert.NoError(t, err)
+
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, &corev1.ConfigMap{})
+		assert.NoError(t, err)
+	})
+	t.Run("after update hooks are chained and results merged", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		var calls []string
+
+		hook := func(name string, result reconcile.Result) operation.HookWithContextFunc {
+			return func(context.Context, interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				calls = append(calls, name)
+				return result, nil
+			}
+		}
+
+		result, err := operation.Update(operation.Conf{
+			Instance:        i,
+			Reconcile:       r,
+			Object:          &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			MaybeUpdateFunc: func(interfaces.Object, interfaces.Object) (bool, error) { return true, nil },
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				calls = append(calls, "single")
+				return reconcile.Result{RequeueAfter: time.Minute}, nil
+			},
+			AfterUpdateHooks: []operation.HookWithContextFunc{
+				hook("first", reconcile.Result{RequeueAfter: time.Second}),
+				hook("second", reconcile.Result{Requeue: true}),
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"single", "first", "second"}, calls)
+		assert.Equal(t, reconcile.Result{Requeue: true, RequeueAfter: time.Second}, result)
+	})
+	t.Run("after delete hooks stop at the failure", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		called := false
+
+		_, err := operation.Delete(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			AfterDeleteHooks: []operation.HookWithContextFunc{
+				func(context.Context, interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+					return reconcile.Result{}, errors.New("test error")
+				},
+				func(context.Context, interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+					called = true
+					return reconcile.Result{}, nil
+				},
+			},
+		})
+		assert.Error(t, err)
+		assert.False(t, called)
+	})
+}
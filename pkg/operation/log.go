@@ -0,0 +1,89 @@
+package operation
+
+import (
+	"context"
+	"time"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/logging"
+
+	"github.com/go-logr/logr"
+)
+
+// logger returns Logger from Conf if set, or else the logger from the
+// context or the Reconcile struct, see logging.Get.
+func logger(ctx context.Context, c Conf) logr.Logger {
+	if c.Logger != nil {
+		return c.Logger
+	}
+	return logging.Get(ctx, c.Reconcile)
+}
+
+// operationLog collects what an operation did to the Object so that
+// it can be logged once the operation is done.
+type operationLog struct {
+	logr.Logger
+	c      Conf
+	start  time.Time
+	action Action
+	values []interface{}
+}
+
+// newOperationLog starts the log of the operation which is to take
+// the action passed on the Object.
+func newOperationLog(ctx context.Context, c Conf, action Action) *operationLog {
+	return &operationLog{Logger: logger(ctx, c), c: c, start: time.Now(), action: action}
+}
+
+// vetoed marks the operation as vetoed by a hook.
+func (l *operationLog) vetoed() {
+	l.action = ActionNoop
+	l.values = append(l.values, logging.VetoedKey, true)
+}
+
+// done logs the outcome of the operation. Failures are logged as
+// errors, changes at logging.ChangeLevel and the rest at
+// logging.DebugLevel. Conflicts are expected when reconciles overlap,
+// so they are logged at logging.DebugLevel too.
+func (l *operationLog) done(err error) {
+	if l.c.Object == nil {
+		return
+	}
+
+	level := logging.ChangeLevel
+	if l.action == ActionNoop || IsConflictError(err) {
+		level = logging.DebugLevel
+	}
+	if (err == nil || IsConflictError(err)) && !l.V(level).Enabled() {
+		return
+	}
+
+	values := []interface{}{
+		logging.KindKey, gvkFor(l.c).Kind,
+		logging.NamespaceKey, l.c.Object.GetNamespace(),
+		logging.NameKey, l.c.Object.GetName(),
+		logging.ActionKey, string(l.action),
+		logging.DurationKey, time.Since(l.start),
+	}
+	if l.c.DryRun != NoDryRun {
+		values = append(values, logging.DryRunKey, true)
+	}
+	values = append(values, l.values...)
+
+	if IsConflictError(err) {
+		l.V(level).Info("operation conflicted", append(values, logging.ErrorKey, err.Error())...)
+		return
+	}
+	if err != nil {
+		l.Error(err, "operation failed", values...)
+		return
+	}
+
+	l.V(level).Info("object "+pastTense[l.action], values...)
+}
+
+var pastTense = map[Action]string{
+	ActionCreate: "created",
+	ActionUpdate: "updated",
+	ActionDelete: "deleted",
+	ActionNoop:   "unchanged",
+}
@@ -0,0 +1,295 @@
+package operation_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/logging"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// logEntry is a single log written to recordingLogger.
+type logEntry struct {
+	level  int
+	msg    string
+	err    error
+	values map[string]interface{}
+}
+
+// recordingLogger records the logs up to the verbosity level.
+type recordingLogger struct {
+	level   int
+	verbose int
+	entries *[]logEntry
+}
+
+func newRecordingLogger(verbose int) recordingLogger {
+	return recordingLogger{verbose: verbose, entries: &[]logEntry{}}
+}
+
+func (l recordingLogger) add(msg string, err error, keysAndValues []interface{}) {
+	values := map[string]interface{}{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		values[keysAndValues[i].(string)] = keysAndValues[i+1]
+	}
+	*l.entries = append(*l.entries, logEntry{level: l.level, msg: msg, err: err, values: values})
+}
+
+func (l recordingLogger) Info(msg string, keysAndValues ...interface{}) {
+	if l.Enabled() {
+		l.add(msg, nil, keysAndValues)
+	}
+}
+
+func (l recordingLogger) Enabled() bool { return l.level <= l.verbose }
+
+func (l recordingLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.add(msg, err, keysAndValues)
+}
+
+func (l recordingLogger) V(level int) logr.InfoLogger {
+	l.level = level
+	return l
+}
+
+func (l recordingLogger) WithValues(...interface{}) logr.Logger { return l }
+func (l recordingLogger) WithName(string) logr.Logger           { return l }
+
+// loggerReconcile is the reconcile struct which also implements
+// interfaces.Logger.
+type loggerReconcile struct {
+	*mocks.MockReconcile
+	logger logr.Logger
+}
+
+func (r *loggerReconcile) GetLogger() logr.Logger {
+	return r.logger
+}
+
+func TestLogging(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	updateData := func(existing interfaces.Object, new interfaces.Object) (bool, error) {
+		e := existing.(*corev1.ConfigMap)
+		n := new.(*corev1.ConfigMap)
+		if e.Data["key1"] == n.Data["key1"] {
+			return false, nil
+		}
+		e.Data["key1"] = n.Data["key1"]
+		return true, nil
+	}
+	assertEntry := func(t *testing.T, logger recordingLogger, level int, msg string, values map[string]interface{}) {
+		if !assert.Len(t, *logger.entries, 1) {
+			return
+		}
+		entry := (*logger.entries)[0]
+		assert.Equal(t, level, entry.level)
+		assert.Equal(t, msg, entry.msg)
+		assert.IsType(t, time.Duration(0), entry.values[logging.DurationKey])
+		for k, v := range values {
+			assert.Equal(t, v, entry.values[k], k)
+		}
+	}
+
+	t.Run("create configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		logger := newRecordingLogger(logging.ChangeLevel)
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+
+		_, err := operation.Create(operation.Conf{Instance: i, Reconcile: r, Object: object, Logger: logger})
+		assert.NoError(t, err)
+		assertEntry(t, logger, logging.ChangeLevel, "object created", map[string]interface{}{
+			logging.KindKey:      "ConfigMap",
+			logging.NamespaceKey: "test",
+			logging.NameKey:      "test-configmap",
+			logging.ActionKey:    "create",
+		})
+	})
+	t.Run("create or update existing configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		logger := newRecordingLogger(logging.ChangeLevel)
+		object := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+			Data:       map[string]string{"key1": "new"},
+		}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{Instance: i, Reconcile: r, Object: object, MaybeUpdateFunc: updateData, Logger: logger})
+		assert.NoError(t, err)
+		assertEntry(t, logger, logging.ChangeLevel, "object updated", map[string]interface{}{
+			logging.NameKey:            "test-existing-configmap",
+			logging.ActionKey:          "update",
+			logging.UpdateRequestedKey: true,
+			logging.DiffKey:            []operation.FieldDiff{{Path: "/data/key1", Old: "value1", New: "new"}},
+		})
+	})
+	t.Run("update up-to-date configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		logger := newRecordingLogger(logging.DebugLevel)
+		object := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+			Data:       map[string]string{"key1": "value1"},
+		}
+
+		_, err := operation.Update(operation.Conf{Instance: i, Reconcile: r, Object: object, MaybeUpdateFunc: updateData, Logger: logger})
+		assert.NoError(t, err)
+		assertEntry(t, logger, logging.DebugLevel, "object unchanged", map[string]interface{}{
+			logging.ActionKey:          "noop",
+			logging.UpdateRequestedKey: false,
+		})
+	})
+	t.Run("noop is not logged at change level", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		logger := newRecordingLogger(logging.ChangeLevel)
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+
+		_, err := operation.Delete(operation.Conf{Instance: i, Reconcile: r, Object: object, Logger: logger})
+		assert.NoError(t, err)
+		assert.Empty(t, *logger.entries)
+	})
+	t.Run("update configmap fails", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		logger := newRecordingLogger(0)
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+
+		_, err := operation.Update(operation.Conf{Instance: i, Reconcile: r, Object: object, MaybeUpdateFunc: updateData, Logger: logger})
+		assert.Error(t, err)
+		assertEntry(t, logger, 0, "operation failed", map[string]interface{}{
+			logging.ActionKey:          "update",
+			logging.UpdateRequestedKey: false,
+		})
+		assert.Error(t, (*logger.entries)[0].err)
+	})
+	t.Run("delete vetoed by hook", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		logger := newRecordingLogger(logging.DebugLevel)
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}}
+
+		_, err := operation.Delete(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    object,
+			Logger:    logger,
+			BeforeDeleteHooks: []operation.BeforeHookFunc{
+				func(context.Context, interfaces.Object, interfaces.Reconcile, interfaces.Object) (reconcile.Result, error) {
+					return reconcile.Result{}, operation.ErrVeto
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assertEntry(t, logger, logging.DebugLevel, "object unchanged", map[string]interface{}{
+			logging.ActionKey: "noop",
+			logging.VetoedKey: true,
+		})
+	})
+	t.Run("logger from context", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		logger := newRecordingLogger(logging.ChangeLevel)
+		ctx := logging.NewContext(context.TODO(), logger)
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}}
+
+		_, err := operation.DeleteWithContext(ctx, operation.Conf{Instance: i, Reconcile: r, Object: object})
+		assert.NoError(t, err)
+		assertEntry(t, logger, logging.ChangeLevel, "object deleted", map[string]interface{}{
+			logging.ActionKey: "delete",
+		})
+	})
+	t.Run("logger from reconcile", func(t *testing.T) {
+		i, mr := mockSetup(controller)
+		logger := newRecordingLogger(logging.ChangeLevel)
+		r := &loggerReconcile{MockReconcile: mr, logger: logger}
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+
+		_, err := operation.Create(operation.Conf{Instance: i, Reconcile: r, Object: object, DryRun: operation.ServerDryRun})
+		assert.NoError(t, err)
+		assertEntry(t, logger, logging.ChangeLevel, "object created", map[string]interface{}{
+			logging.ActionKey: "create",
+			logging.DryRunKey: true,
+		})
+	})
+	t.Run("logger from conf takes precedence", func(t *testing.T) {
+		i, mr := mockSetup(controller)
+		logger := newRecordingLogger(logging.ChangeLevel)
+		other := newRecordingLogger(logging.ChangeLevel)
+		r := &loggerReconcile{MockReconcile: mr, logger: other}
+		ctx := logging.NewContext(context.TODO(), other)
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}}
+
+		_, err := operation.CreateWithContext(ctx, operation.Conf{Instance: i, Reconcile: r, Object: object, Logger: logger})
+		assert.NoError(t, err)
+		assert.Len(t, *logger.entries, 1)
+		assert.Empty(t, *other.entries)
+	})
+	t.Run("create existing configmap fails", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		logger := newRecordingLogger(logging.DebugLevel)
+		object := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}}
+
+		_, err := operation.Create(operation.Conf{Instance: i, Reconcile: r, Object: object, Logger: logger})
+		assert.Error(t, err)
+		assertEntry(t, logger, 0, "operation failed", map[string]interface{}{
+			logging.NameKey:   "test-existing-configmap",
+			logging.ActionKey: "create",
+		})
+	})
+	t.Run("create or update existing configmap logs update only", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		logger := newRecordingLogger(logging.DebugLevel)
+		object := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+			Data:       map[string]string{"key1": "value1"},
+		}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{Instance: i, Reconcile: r, Object: object, MaybeUpdateFunc: updateData, Logger: logger})
+		assert.NoError(t, err)
+		assertEntry(t, logger, logging.DebugLevel, "object unchanged", map[string]interface{}{
+			logging.ActionKey: "noop",
+		})
+	})
+	t.Run("conflict is logged at debug level", func(t *testing.T) {
+		i, fr := mockSetup(controller)
+		r := mocks.NewMockReconcile(controller)
+		r.EXPECT().GetClient().Return(&conflictClient{Client: fr.GetClient(), conflicts: 1}).AnyTimes()
+		r.EXPECT().GetScheme().Return(fr.GetScheme()).AnyTimes()
+		logger := newRecordingLogger(logging.DebugLevel)
+		object := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+			Data:       map[string]string{"key1": "new"},
+		}
+
+		_, err := operation.Update(operation.Conf{Instance: i, Reconcile: r, Object: object, MaybeUpdateFunc: updateData, Logger: logger})
+		assert.True(t, operation.IsConflictError(err))
+		assertEntry(t, logger, logging.DebugLevel, "operation conflicted", map[string]interface{}{
+			logging.ActionKey: "update",
+		})
+		if assert.Len(t, *logger.entries, 1) {
+			assert.Nil(t, (*logger.entries)[0].err)
+			assert.Contains(t, (*logger.entries)[0].values[logging.ErrorKey], "test error")
+		}
+	})
+	t.Run("conflict is not logged at change level", func(t *testing.T) {
+		i, fr := mockSetup(controller)
+		r := mocks.NewMockReconcile(controller)
+		r.EXPECT().GetClient().Return(&conflictClient{Client: fr.GetClient(), conflicts: 1}).AnyTimes()
+		r.EXPECT().GetScheme().Return(fr.GetScheme()).AnyTimes()
+		logger := newRecordingLogger(logging.ChangeLevel)
+		object := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+			Data:       map[string]string{"key1": "new"},
+		}
+
+		_, err := operation.Update(operation.Conf{Instance: i, Reconcile: r, Object: object, MaybeUpdateFunc: updateData, Logger: logger})
+		assert.True(t, operation.IsConflictError(err))
+		assert.Empty(t, *logger.entries)
+	})
+}
@@ -2,10 +2,20 @@ package operation
 
 import (
 	"context"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/logging"
 
 	"github.com/pkg/errors"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -16,32 +26,83 @@ import (
 // Objects. However, this can also be used to create Custom Objects
 // (or unsupported objects).
 func Create(c Conf) (reconcile.Result, error) {
-	return create(c)
+	return create(context.Background(), c, false)
+}
+
+// CreateWithContext is same as Create but uses the context passed for
+// the calls to API Server and the hooks.
+func CreateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
+	return create(ctx, c, false)
 }
 
-func create(c Conf) (r reconcile.Result, err error) {
-	client := c.Reconcile.GetClient()
+// create creates the Object. existsHandled is set when the caller
+// handles the Object which exists already, like CreateOrUpdate falls
+// back to Update, so that is neither logged nor recorded as failure.
+func create(ctx context.Context, c Conf, existsHandled bool) (r reconcile.Result, err error) {
+	log := newOperationLog(ctx, c, ActionCreate)
+	defer func() {
+		if !existsHandled || !kerrors.IsAlreadyExists(errors.Cause(err)) {
+			log.done(err)
+		}
+	}()
+
+	cl := c.Reconcile.GetClient()
+
+	r, vetoed, err := runBeforeHooks(ctx, c, c.Object, c.BeforeCreateHooks)
+	if err != nil {
+		return r, errors.Wrap(err, "failed to run BeforeCreate hook")
+	}
+	if vetoed {
+		log.vetoed()
+		return r, nil
+	}
 
 	if c.OwnerReference {
 		err = controllerutil.SetControllerReference(c.Instance, c.Object, c.Reconcile.GetScheme())
 		if err != nil {
-			return reconcile.Result{}, errors.Wrapf(err, "failed to set owner reference on the object")
+			return r, errors.Wrapf(err, "failed to set owner reference on the object")
 		}
 	}
 
-	err = client.Create(context.TODO(), c.Object)
-	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to create the object in cluster")
+	// The Object is changed by the response, so keep a copy of it
+	// for the plan.
+	var object runtime.Object
+	if c.Plan != nil {
+		object = c.Object.DeepCopyObject()
 	}
+	if c.DryRun != NoDryRun {
+		err = checkNotExists(ctx, c)
+	}
+	if err == nil && c.DryRun != ClientDryRun {
+		var opts []client.CreateOption
+		if c.DryRun == ServerDryRun {
+			opts = append(opts, client.DryRunAll)
+		}
 
-	if c.AfterCreateFunc != nil {
-		r, err = c.AfterCreateFunc(c.Instance, c.Reconcile)
-		if err != nil {
-			return r, errors.Wrap(err, "failed to run AfterCreate hook")
+		cctx, cancel := CallContext(ctx, c.Timeout)
+		err = cl.Create(cctx, c.Object, opts...)
+		cancel()
+	}
+	if err != nil {
+		if !existsHandled || !kerrors.IsAlreadyExists(err) {
+			recordFailure(c, eventReasons(c).CreateFailed, "create", err)
 		}
+		return r, errors.Wrap(err, "failed to create the object in cluster")
+	}
+	recordSuccess(c, eventReasons(c).Created, "Created")
+
+	err = recordChange(c, ActionCreate, nil, object)
+	if err != nil || c.DryRun != NoDryRun {
+		return r, err
+	}
+
+	ar, err := runAfterHooks(ctx, c, HookWithContextFunc(c.AfterCreateWithContextFunc), HookFunc(c.AfterCreateFunc), c.AfterCreateHooks)
+	r = MergeResults(r, ar)
+	if err != nil {
+		return r, errors.Wrap(err, "failed to run AfterCreate hook")
 	}
 
-	return reconcile.Result{}, nil
+	return r, nil
 }
 
 // Update is a generic update function for any Kubernetes Object. This
@@ -50,89 +111,490 @@ func create(c Conf) (r reconcile.Result, err error) {
 // by other Objects. This can also be used to implement custom update
 // logic on any Kubernetes Object. It fetches the Object with same
 // metadata as the specified object from the cluster and update them
-// using MaybeUpdateFunc.
+// using MaybeUpdateFunc. If ExistingObject is not set in Conf, an
+// empty object of the same type as Object is used. If the update
+// fails with conflict, it returns ConflictError. Set ConflictBackoff
+// in Conf to retry instead. Use UpdateWithDiff to get the fields
+// changed as well.
 func Update(c Conf) (reconcile.Result, error) {
-	return update(c)
+	return update(context.Background(), c)
+}
+
+// UpdateWithContext is same as Update but uses the context passed for
+// the calls to API Server and the hooks.
+func UpdateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
+	return update(ctx, c)
+}
+
+// UpdateWithDiff is same as Update but also returns the fields
+// changed, with the values of RedactPaths from Conf redacted. The
+// diff is empty if the Object is not updated, or would not be in
+// dry-run mode.
+func UpdateWithDiff(c Conf) (reconcile.Result, []FieldDiff, error) {
+	return updateWithDiff(context.Background(), c)
+}
+
+// UpdateWithDiffWithContext is same as UpdateWithDiff but uses the
+// context passed for the calls to API Server and the hooks.
+func UpdateWithDiffWithContext(ctx context.Context, c Conf) (reconcile.Result, []FieldDiff, error) {
+	return updateWithDiff(ctx, c)
+}
+
+// updateWithDiff updates the Object and captures the fields changed
+// through DiffHook, which is called with them once the Object is
+// updated. The DiffHook from Conf, if set, is still called.
+func updateWithDiff(ctx context.Context, c Conf) (reconcile.Result, []FieldDiff, error) {
+	var diff []FieldDiff
+	hook := c.DiffHook
+	c.DiffHook = func(ctx context.Context, instance interfaces.Object, object interfaces.Object, d []FieldDiff) {
+		diff = d
+		if hook != nil {
+			hook(ctx, instance, object, d)
+		}
+	}
+
+	r, err := update(ctx, c)
+	return r, diff, err
+}
+
+func update(ctx context.Context, c Conf) (r reconcile.Result, err error) {
+	log := newOperationLog(ctx, c, ActionUpdate)
+	defer func() { log.done(err) }()
+
+	r, vetoed, err := runBeforeHooks(ctx, c, c.Object, c.BeforeUpdateHooks)
+	if err != nil {
+		return r, errors.Wrap(err, "failed to run BeforeUpdate hook")
+	}
+	if vetoed {
+		log.vetoed()
+		return r, nil
+	}
+
+	if c.ExistingObject == nil && c.Object != nil {
+		c.ExistingObject, err = newObject(c.Object)
+		if err != nil {
+			return r, errors.Wrap(err, "failed to create the existing object")
+		}
+	}
+
+	var requireUpdate bool
+	var diff []FieldDiff
+	if c.ConflictBackoff != nil {
+		requireUpdate, diff, err = retryOnConflict(ctx, c)
+	} else {
+		requireUpdate, diff, err = tryUpdate(ctx, c)
+	}
+	if err == nil && !requireUpdate {
+		log.action = ActionNoop
+	}
+	log.values = append(log.values, logging.UpdateRequestedKey, requireUpdate)
+	if len(diff) > 0 {
+		log.values = append(log.values, logging.DiffKey, diff)
+	}
+	// Conflicts are expected when reconciles overlap and immutable
+	// fields are left to the caller to handle, so neither is worth a
+	// Warning Event.
+	if err != nil && !kerrors.IsConflict(errors.Cause(err)) && !IsImmutableFieldError(err) {
+		recordFailure(c, eventReasons(c).UpdateFailed, "update", err)
+	}
+	if kerrors.IsConflict(errors.Cause(err)) {
+		return r, NewConflictError(c.Object.GetName(), c.Object.GetNamespace(), errors.Cause(err))
+	}
+	if err != nil || c.DryRun != NoDryRun {
+		return r, err
+	}
+
+	ar, err := runAfterHooks(ctx, c, HookWithContextFunc(c.AfterUpdateWithContextFunc), HookFunc(c.AfterUpdateFunc), c.AfterUpdateHooks)
+	r = MergeResults(r, ar)
+	if err != nil {
+		return r, errors.Wrap(err, "failed to run AfterUpdate hook")
+	}
+
+	return r, nil
 }
 
-func update(c Conf) (r reconcile.Result, err error) {
-	client := c.Reconcile.GetClient()
+// tryUpdate fetches the existing object from cluster, compares it
+// using MaybeUpdateFunc and sends the changes, if any. It reports if
+// the update is required along with the redacted diff, if computed.
+func tryUpdate(ctx context.Context, c Conf) (bool, []FieldDiff, error) {
+	cl := c.Reconcile.GetClient()
+
+	cctx, cancel := CallContext(ctx, c.Timeout)
+	err := cl.Get(cctx, types.NamespacedName{Name: c.Object.GetName(), Namespace: c.Object.GetNamespace()}, c.ExistingObject)
+	cancel()
+	if err != nil {
+		return false, nil, errors.Wrap(err, "failed to get the existing object from cluster")
+	}
 
-	// TODO: Fix this (if possible) to automatically figure out the
-	// Kind and initialize the empty struct and point to that. For now
-	// this returns a nil pointer of the Object's Kind. However,
-	// client functions require pointer to empty struct.
-	// existingObject, ok := reflect.New(reflect.TypeOf(c.Object)).Elem().Interface().(interfaces.Object)
-	// if !ok {
-	//	return reconcile.Result{}, errors.New("failed to create new instance of the object type")
-	// }
+	// Patches are computed against the fetched object, so keep a copy
+	// before MaybeUpdateFunc changes it.
+	original := c.ExistingObject.DeepCopyObject()
 
-	err = client.Get(context.TODO(), types.NamespacedName{Name: c.Object.GetName(), Namespace: c.Object.GetNamespace()}, c.ExistingObject)
+	wantDiff := c.Plan != nil || c.DiffHook != nil || logger(ctx, c).V(logging.ChangeLevel).Enabled()
+	requireUpdate, diff, err := maybeUpdate(c, original, wantDiff)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to get the existing object from cluster")
+		return false, nil, errors.Wrap(err, "failed to update the object")
+	}
+
+	if !requireUpdate {
+		record(c, ActionNoop, nil)
+		return false, nil, nil
+	}
+
+	diff = Redact(diff, c.RedactPaths...)
+	if err = send(ctx, c, original); err != nil {
+		return true, diff, err
+	}
+	recordSuccess(c, eventReasons(c).Updated, "Updated")
+
+	record(c, ActionUpdate, diff)
+	if c.DiffHook != nil {
+		c.DiffHook(ctx, c.Instance, c.Object, diff)
+	}
+
+	return true, diff, nil
+}
+
+// maybeUpdate updates the ExistingObject using MaybeUpdateWithDiffFunc
+// if set, or else MaybeUpdateFunc. For the latter, the diff is
+// computed against the original object only if it is wanted.
+func maybeUpdate(c Conf, original runtime.Object, wantDiff bool) (bool, []FieldDiff, error) {
+	if c.MaybeUpdateWithDiffFunc != nil {
+		diff, err := c.MaybeUpdateWithDiffFunc(c.ExistingObject, c.Object)
+		return len(diff) > 0, diff, err
 	}
 
 	requireUpdate, err := c.MaybeUpdateFunc(c.ExistingObject, c.Object)
+	if err != nil || !requireUpdate {
+		return requireUpdate, nil, err
+	}
+
+	if !wantDiff {
+		return true, nil, nil
+	}
+
+	diff, err := Diff(original, c.ExistingObject)
 	if err != nil {
-		return reconcile.Result{}, errors.Wrap(err, "failed to update the object")
+		return false, nil, errors.Wrap(err, "failed to compute the diff of the object")
 	}
 
-	if requireUpdate {
-		err = client.Update(context.TODO(), c.ExistingObject)
-		if err != nil {
-			return reconcile.Result{}, errors.Wrap(err, "failed to update the object in cluster")
+	return true, diff, nil
+}
+
+// retryOnConflict calls tryUpdate until it does not fail with
+// conflict, waiting between the attempts as per ConflictBackoff. Every
+// attempt fetches the object into a fresh copy of ExistingObject, so
+// the changes from the failed attempt do not leak into the next one.
+// At least one attempt is made even if Steps of ConflictBackoff is
+// not set, and the wait is cut short if the context is done. It
+// reports the outcome of the last attempt like tryUpdate.
+func retryOnConflict(ctx context.Context, c Conf) (bool, []FieldDiff, error) {
+	empty := c.ExistingObject.DeepCopyObject()
+	backoff := *c.ConflictBackoff
+
+	for {
+		attempt := c
+		attempt.ExistingObject = empty.DeepCopyObject().(interfaces.Object)
+
+		requireUpdate, diff, err := tryUpdate(ctx, attempt)
+		if !kerrors.IsConflict(errors.Cause(err)) || backoff.Steps <= 1 {
+			return requireUpdate, diff, err
+		}
+
+		timer := time.NewTimer(backoff.Step())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return false, nil, errors.Wrap(ctx.Err(), "failed to retry the update")
+		case <-timer.C:
 		}
 	}
+}
 
-	if c.AfterUpdateFunc != nil {
-		r, err = c.AfterUpdateFunc(c.Instance, c.Reconcile)
-		if err != nil {
-			return r, errors.Wrap(err, "failed to run AfterUpdate hook")
+// send sends the changes made to ExistingObject to API Server using
+// the UpdateStrategy from Conf. Nothing is sent in ClientDryRun mode.
+func send(ctx context.Context, c Conf, original runtime.Object) error {
+	cl := c.Reconcile.GetClient()
+
+	if c.DryRun == ClientDryRun {
+		return nil
+	}
+
+	if c.UpdateStrategy == FullUpdate {
+		var opts []client.UpdateOption
+		if c.DryRun == ServerDryRun {
+			opts = append(opts, client.DryRunAll)
 		}
+
+		cctx, cancel := CallContext(ctx, c.Timeout)
+		defer cancel()
+		return errors.Wrap(cl.Update(cctx, c.ExistingObject, opts...), "failed to update the object in cluster")
+	}
+
+	patch, err := patchFor(c.UpdateStrategy, original, c.ExistingObject)
+	if err != nil {
+		return errors.Wrap(err, "failed to create patch for the object")
 	}
 
-	return reconcile.Result{}, nil
+	var opts []client.PatchOption
+	if c.DryRun == ServerDryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+
+	cctx, cancel := CallContext(ctx, c.Timeout)
+	defer cancel()
+	return errors.Wrap(cl.Patch(cctx, c.ExistingObject, patch, opts...), "failed to patch the object in cluster")
 }
 
 // CreateOrUpdate is the combination of Create and Update. It can be
 // used to create any Kubernetes Object. It catches the
-// "IsAlreadyExists" error and tries to update the object.
-func CreateOrUpdate(c Conf) (r reconcile.Result, err error) {
-	r, err = create(c)
+// "IsAlreadyExists" error and tries to update the object. If Apply is
+// set in Conf, the object is instead sent as a server-side apply
+// patch, see Apply field of Conf for details. In apply mode, the
+// AfterUpdate hooks are called after the patch, unless EmulateApply
+// is set and the object is created, in which case the AfterCreate
+// hooks are called. The fields changed are available through Plan or
+// DiffHook in Conf. If BeforeCreateHooks are set, the
+// Object is looked up first, so that they are only called when the
+// Object is to be created and cannot veto or change its updates.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	return createOrUpdate(context.Background(), c)
+}
+
+// CreateOrUpdateWithContext is same as CreateOrUpdate but uses the
+// context passed for the calls to API Server and the hooks.
+func CreateOrUpdateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
+	return createOrUpdate(ctx, c)
+}
+
+func createOrUpdate(ctx context.Context, c Conf) (r reconcile.Result, err error) {
+	if c.Apply {
+		return apply(ctx, c)
+	}
+
+	if len(c.BeforeCreateHooks) > 0 {
+		existing, err := fetch(ctx, c)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if existing != nil {
+			return update(ctx, c)
+		}
+	}
+
+	r, err = create(ctx, c, true)
 	if err != nil && !kerrors.IsAlreadyExists(errors.Cause(err)) {
-		return r, errors.Wrap(err, "adsadA")
+		return r, errors.Wrap(err, "failed to create or update the object")
 	}
 
 	if kerrors.IsAlreadyExists(errors.Cause(err)) {
-		return update(c)
+		return update(ctx, c)
 	}
 
 	return r, nil
 }
 
+// DefaultDeletionPollInterval is the interval after which the
+// reconcile is requeued while waiting for the deletion to finish, if
+// DeletionPollInterval is not set in Conf.
+const DefaultDeletionPollInterval = 5 * time.Second
+
 // Delete is a generic delete function for any Kubernetes Object. This
 // function can be used to delete any Kubernetes Object defined in
 // Conf. This is a lower-level function which is supposed to be used
 // by other Kubernetes Objects. This can also be used to delete any
-// Custom Objects (or unsupported Objects).
+// Custom Objects (or unsupported Objects). If WaitForDeletion is set
+// in Conf, it returns the result with RequeueAfter until the Object
+// is gone and calls the AfterDelete hooks only after that.
 func Delete(c Conf) (reconcile.Result, error) {
-	return delete(c)
+	return delete(context.Background(), c)
+}
+
+// DeleteWithContext is same as Delete but uses the context passed for
+// the calls to API Server and the hooks.
+func DeleteWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
+	return delete(ctx, c)
 }
 
-func delete(c Conf) (r reconcile.Result, err error) {
-	client := c.Reconcile.GetClient()
+func delete(ctx context.Context, c Conf) (r reconcile.Result, err error) {
+	log := newOperationLog(ctx, c, ActionDelete)
+	defer func() { log.done(err) }()
 
-	err = client.Delete(context.TODO(), c.Object)
-	if err != nil && !kerrors.IsNotFound(err) {
-		return reconcile.Result{}, errors.Wrap(err, "failed to delete the object in cluster")
+	cl := c.Reconcile.GetClient()
+
+	r, vetoed, err := runBeforeHooks(ctx, c, c.Object, c.BeforeDeleteHooks)
+	if err != nil {
+		return r, errors.Wrap(err, "failed to run BeforeDelete hook")
+	}
+	if vetoed {
+		log.vetoed()
+		return r, nil
 	}
 
-	if c.AfterDeleteFunc != nil {
-		r, err = c.AfterDeleteFunc(c.Instance, c.Reconcile)
+	// The delete request is not sent or does not tell in dry-run mode
+	// if the object exists, so look it up first. While waiting for the
+	// deletion, the object is looked up too, so that the request is
+	// sent only once and not again on every poll, which would fail
+	// with resourceVersion precondition.
+	gone, pending := false, false
+	if c.DryRun != NoDryRun || c.WaitForDeletion {
+		existing, err := fetch(ctx, c)
 		if err != nil {
-			return r, errors.Wrap(err, "failed to run AfterDelete hook")
+			return r, err
+		}
+		if existing == nil && c.DryRun != NoDryRun {
+			log.action = ActionNoop
+			record(c, ActionNoop, nil)
+			return r, nil
+		}
+		gone = existing == nil
+		pending = c.WaitForDeletion && existing != nil && existing.GetDeletionTimestamp() != nil
+	}
+
+	if pending {
+		log.action = ActionNoop
+		record(c, ActionNoop, nil)
+		return MergeResults(r, reconcile.Result{RequeueAfter: deletionPollInterval(c)}), nil
+	}
+
+	if !gone && c.DryRun != ClientDryRun {
+		opts := deleteOptions(c)
+		if c.DryRun == ServerDryRun {
+			opts = append(opts, client.DryRunAll)
 		}
+
+		cctx, cancel := CallContext(ctx, c.Timeout)
+		err = cl.Delete(cctx, c.Object, opts...)
+		cancel()
+		if err != nil && !kerrors.IsNotFound(err) {
+			recordFailure(c, eventReasons(c).DeleteFailed, "delete", err)
+			return r, errors.Wrap(err, "failed to delete the object in cluster")
+		}
+	}
+
+	action := ActionDelete
+	if gone || kerrors.IsNotFound(err) {
+		action = ActionNoop
+	} else {
+		recordSuccess(c, eventReasons(c).Deleted, "Deleted")
+	}
+
+	log.action = action
+	record(c, action, nil)
+	if c.DryRun != NoDryRun {
+		return r, nil
+	}
+
+	if c.WaitForDeletion && action == ActionDelete {
+		existing, err := fetch(ctx, c)
+		if err != nil {
+			return r, err
+		}
+		if existing != nil {
+			return MergeResults(r, reconcile.Result{RequeueAfter: deletionPollInterval(c)}), nil
+		}
+	}
+
+	ar, err := runAfterHooks(ctx, c, HookWithContextFunc(c.AfterDeleteWithContextFunc), HookFunc(c.AfterDeleteFunc), c.AfterDeleteHooks)
+	r = MergeResults(r, ar)
+	if err != nil {
+		return r, errors.Wrap(err, "failed to run AfterDelete hook")
+	}
+
+	return r, nil
+}
+
+// deleteOptions returns the options for the delete request as per
+// Conf.
+func deleteOptions(c Conf) []client.DeleteOption {
+	var opts []client.DeleteOption
+	if c.PropagationPolicy != "" {
+		opts = append(opts, client.PropagationPolicy(c.PropagationPolicy))
+	}
+	if c.Preconditions != nil {
+		opts = append(opts, client.Preconditions(*c.Preconditions))
+	}
+	if c.GracePeriodSeconds != nil {
+		opts = append(opts, client.GracePeriodSeconds(*c.GracePeriodSeconds))
+	}
+	return opts
+}
+
+// deletionPollInterval returns the interval after which the reconcile
+// is requeued while waiting for the deletion to finish.
+func deletionPollInterval(c Conf) time.Duration {
+	if c.DeletionPollInterval > 0 {
+		return c.DeletionPollInterval
+	}
+	return DefaultDeletionPollInterval
+}
+
+// CallContext derives the context for a single call to API Server.
+// The call is cancelled after the timeout, if it is positive. It is
+// used by the packages which take Timeout in their `Conf` to limit
+// every call they make.
+func CallContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// fetch gets the in-cluster version of the Object into a new object
+// of the same type. It returns nil if the object does not exist.
+func fetch(ctx context.Context, c Conf) (interfaces.Object, error) {
+	existing, err := newObject(c.Object)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the existing object")
+	}
+
+	cctx, cancel := CallContext(ctx, c.Timeout)
+	defer cancel()
+	err = c.Reconcile.GetClient().Get(cctx, types.NamespacedName{Name: c.Object.GetName(), Namespace: c.Object.GetNamespace()}, existing)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the existing object from cluster")
+	}
+
+	return existing, nil
+}
+
+// checkNotExists returns the error API Server would return on create
+// if the Object already exists. It is used in dry-run mode, where the
+// create request is either not sent or, with the fake client, does
+// not fail, so that CreateOrUpdate still falls back to Update.
+func checkNotExists(ctx context.Context, c Conf) error {
+	existing, err := fetch(ctx, c)
+	if err != nil || existing == nil {
+		return err
+	}
+
+	gvk := gvkFor(c)
+	return kerrors.NewAlreadyExists(schema.GroupResource{Group: gvk.Group, Resource: strings.ToLower(gvk.Kind)}, c.Object.GetName())
+}
+
+// newObject returns an empty object of the same type as the object
+// passed. The type of Unstructured objects is in their content, so it
+// is copied over. For the rest, including custom resources, a new
+// value of the concrete type is created.
+func newObject(o interfaces.Object) (interfaces.Object, error) {
+	if u, ok := o.(*unstructured.Unstructured); ok {
+		e := &unstructured.Unstructured{}
+		e.SetGroupVersionKind(u.GroupVersionKind())
+		return e, nil
+	}
+
+	t := reflect.TypeOf(o)
+	if t.Kind() != reflect.Ptr || t.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("object of type %v is not a pointer to struct", t)
+	}
+
+	e, ok := reflect.New(t.Elem()).Interface().(interfaces.Object)
+	if !ok {
+		return nil, errors.Errorf("new instance of %v does not implement Object", t)
 	}
 
-	return reconcile.Result{}, nil
+	return e, nil
 }
@@ -3,20 +3,27 @@ package operation_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
 	"github.com/ankitrgadiya/operatorlib/pkg/operation"
 
 	"github.com/golang/mock/gomock"
+	pkgerrors "github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
@@ -545,3 +552,521 @@ func TestDelete(t *testing.T) {
 		})
 	})
 }
+
+type contextKey struct{}
+
+func TestWithContext(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	ctx := context.WithValue(context.Background(), contextKey{}, "test")
+
+	hook := func(called *bool) operation.HookWithContextFunc {
+		return func(ctx context.Context, _ interfaces.Object, _ interfaces.Reconcile) (reconcile.Result, error) {
+			*called = true
+			if ctx.Value(contextKey{}) != "test" {
+				return reconcile.Result{}, errors.New("context is not passed")
+			}
+			return reconcile.Result{}, nil
+		}
+	}
+	plain := func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+		return reconcile.Result{}, errors.New("plain hook is called")
+	}
+
+	t.Run("create configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		called := false
+
+		_, err := operation.CreateWithContext(ctx, operation.Conf{
+			Instance:                   i,
+			Reconcile:                  r,
+			Object:                     &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}},
+			Timeout:                    time.Second,
+			AfterCreateFunc:            plain,
+			AfterCreateWithContextFunc: operation.AfterCreateWithContextFunc(hook(&called)),
+		})
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+	t.Run("update configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		called := false
+
+		_, err := operation.UpdateWithContext(ctx, operation.Conf{
+			Instance:                   i,
+			Reconcile:                  r,
+			Object:                     &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			ExistingObject:             &corev1.ConfigMap{},
+			Timeout:                    time.Second,
+			MaybeUpdateFunc:            func(interfaces.Object, interfaces.Object) (bool, error) { return true, nil },
+			AfterUpdateFunc:            plain,
+			AfterUpdateWithContextFunc: operation.AfterUpdateWithContextFunc(hook(&called)),
+		})
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+	t.Run("create or update configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		called := false
+
+		_, err := operation.CreateOrUpdateWithContext(ctx, operation.Conf{
+			Instance:                   i,
+			Reconcile:                  r,
+			Object:                     &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			ExistingObject:             &corev1.ConfigMap{},
+			MaybeUpdateFunc:            func(interfaces.Object, interfaces.Object) (bool, error) { return false, nil },
+			AfterUpdateWithContextFunc: operation.AfterUpdateWithContextFunc(hook(&called)),
+		})
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+	t.Run("delete configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		called := false
+
+		_, err := operation.DeleteWithContext(ctx, operation.Conf{
+			Instance:                   i,
+			Reconcile:                  r,
+			Object:                     &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			Timeout:                    time.Second,
+			AfterDeleteFunc:            plain,
+			AfterDeleteWithContextFunc: operation.AfterDeleteWithContextFunc(hook(&called)),
+		})
+		assert.NoError(t, err)
+		assert.True(t, called)
+	})
+	t.Run("context hook fails", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		called := false
+
+		_, err := operation.CreateWithContext(context.Background(), operation.Conf{
+			Instance:                   i,
+			Reconcile:                  r,
+			Object:                     &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}},
+			AfterCreateWithContextFunc: operation.AfterCreateWithContextFunc(hook(&called)),
+		})
+		assert.Error(t, err)
+		assert.True(t, called)
+	})
+}
+
+func TestCreateOrUpdateApply(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("apply configmap which does not exist", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+		created := false
+
+		object := &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"},
+			Data:       map[string]string{"key1": "value1"},
+		}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{
+			Instance:     i,
+			Reconcile:    r,
+			Object:       object,
+			Apply:        true,
+			EmulateApply: true,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				created = true
+				return reconcile.Result{}, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.True(t, created)
+
+		createdObject := &corev1.ConfigMap{}
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-configmap", Namespace: "test"}, createdObject)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"key1": "value1"}, createdObject.Data)
+	})
+	t.Run("apply configmap which exists", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+		updated := false
+
+		object := &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+			Data:       map[string]string{"key2": "new-value2", "key3": "value3"},
+		}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{
+			Instance:       i,
+			Reconcile:      r,
+			Object:         object,
+			Apply:          true,
+			EmulateApply:   true,
+			FieldManager:   "test",
+			ForceConflicts: true,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				updated = true
+				return reconcile.Result{}, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.True(t, updated)
+
+		updatedObject := &corev1.ConfigMap{}
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, updatedObject)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"key1": "value1", "key2": "new-value2", "key3": "value3"}, updatedObject.Data)
+	})
+	t.Run("apply configmap with after update function fails", func(t *testing.T) {
+		i, r := mockSetup(controller)
+
+		object := &corev1.ConfigMap{
+			TypeMeta:   metav1.TypeMeta{Kind: "ConfigMap", APIVersion: "v1"},
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+		}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{
+			Instance:     i,
+			Reconcile:    r,
+			Object:       object,
+			Apply:        true,
+			EmulateApply: true,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdateStrategy(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	maybeUpdate := func(existing interfaces.Object, _ interfaces.Object) (bool, error) {
+		cm := existing.(*corev1.ConfigMap)
+		cm.Data["key2"] = "new-value2"
+		cm.Data["key/3"] = "value3"
+		return true, nil
+	}
+
+	for _, strategy := range []operation.UpdateStrategy{operation.FullUpdate, operation.MergePatch, operation.StrategicMergePatch, operation.JSONPatch} {
+		t.Run(fmt.Sprintf("update configmap data with strategy %d", strategy), func(t *testing.T) {
+			i, r := mockSetup(controller)
+			client := r.GetClient()
+
+			_, err := operation.Update(operation.Conf{
+				Instance:        i,
+				Reconcile:       r,
+				Object:          &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+				ExistingObject:  &corev1.ConfigMap{},
+				MaybeUpdateFunc: maybeUpdate,
+				UpdateStrategy:  strategy,
+			})
+			assert.NoError(t, err)
+
+			updatedObject := &corev1.ConfigMap{}
+			err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, updatedObject)
+			assert.NoError(t, err)
+			assert.Equal(t, map[string]string{"key1": "value1", "key2": "new-value2", "key/3": "value3"}, updatedObject.Data)
+		})
+	}
+	t.Run("unknown strategy", func(t *testing.T) {
+		i, r := mockSetup(controller)
+
+		_, err := operation.Update(operation.Conf{
+			Instance:        i,
+			Reconcile:       r,
+			Object:          &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			ExistingObject:  &corev1.ConfigMap{},
+			MaybeUpdateFunc: maybeUpdate,
+			UpdateStrategy:  operation.UpdateStrategy(100),
+		})
+		assert.Error(t, err)
+	})
+}
+
+// conflictClient fails the first updates with conflict.
+type conflictClient struct {
+	client.Client
+	conflicts int
+	updates   int
+}
+
+func (c *conflictClient) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	c.updates++
+	if c.updates <= c.conflicts {
+		return kerrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, "test-existing-configmap", errors.New("test error"))
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func TestUpdateConflict(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	setup := func(conflicts int) (*mocks.MockObject, *mocks.MockReconcile, *conflictClient) {
+		i, fr := mockSetup(controller)
+		c := &conflictClient{Client: fr.GetClient(), conflicts: conflicts}
+		r := mocks.NewMockReconcile(controller)
+		r.EXPECT().GetClient().Return(c).AnyTimes()
+		r.EXPECT().GetScheme().Return(fr.GetScheme()).AnyTimes()
+		return i, r, c
+	}
+	conf := func(i interfaces.Object, r interfaces.Reconcile) operation.Conf {
+		return operation.Conf{
+			Instance:        i,
+			Reconcile:       r,
+			Object:          &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			ExistingObject:  &corev1.ConfigMap{},
+			MaybeUpdateFunc: func(interfaces.Object, interfaces.Object) (bool, error) { return true, nil },
+		}
+	}
+
+	t.Run("conflict without retries", func(t *testing.T) {
+		i, r, c := setup(1)
+
+		_, err := operation.Update(conf(i, r))
+		assert.True(t, operation.IsConflictError(err))
+		assert.Equal(t, 1, c.updates)
+	})
+	t.Run("conflict resolved by retries", func(t *testing.T) {
+		i, r, c := setup(2)
+		oc := conf(i, r)
+		oc.ConflictBackoff = &wait.Backoff{Steps: 3, Duration: time.Millisecond}
+
+		_, err := operation.Update(oc)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, c.updates)
+	})
+	t.Run("conflict remains after retries", func(t *testing.T) {
+		i, r, c := setup(5)
+		oc := conf(i, r)
+		oc.ConflictBackoff = &wait.Backoff{Steps: 3, Duration: time.Millisecond}
+
+		_, err := operation.CreateOrUpdate(oc)
+		assert.True(t, operation.IsConflictError(err))
+		assert.Equal(t, 3, c.updates)
+	})
+	t.Run("backoff without steps makes single attempt", func(t *testing.T) {
+		i, r, c := setup(0)
+		oc := conf(i, r)
+		oc.ConflictBackoff = &wait.Backoff{}
+
+		_, err := operation.Update(oc)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, c.updates)
+	})
+	t.Run("retries stop when context is done", func(t *testing.T) {
+		i, r, c := setup(5)
+		oc := conf(i, r)
+		oc.ConflictBackoff = &wait.Backoff{Steps: 3, Duration: time.Hour}
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		_, err := operation.UpdateWithContext(ctx, oc)
+		assert.Equal(t, context.Canceled, pkgerrors.Cause(err))
+		assert.Equal(t, 1, c.updates)
+	})
+	t.Run("other errors are not retried", func(t *testing.T) {
+		i, r, c := setup(0)
+		oc := conf(i, r)
+		oc.ConflictBackoff = &wait.Backoff{Steps: 3, Duration: time.Millisecond}
+		oc.MaybeUpdateFunc = func(interfaces.Object, interfaces.Object) (bool, error) { return false, errors.New("test error") }
+
+		_, err := operation.Update(oc)
+		assert.Error(t, err)
+		assert.False(t, operation.IsConflictError(err))
+		assert.Equal(t, 0, c.updates)
+	})
+}
+
+func TestUpdateWithoutExistingObject(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("update configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+
+		_, err := operation.Update(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			MaybeUpdateFunc: func(existing interfaces.Object, _ interfaces.Object) (bool, error) {
+				cm, ok := existing.(*corev1.ConfigMap)
+				if !ok {
+					return false, errors.New("failed to assert the existing object")
+				}
+				cm.Data["key3"] = "value3"
+				return true, nil
+			},
+		})
+		assert.NoError(t, err)
+
+		updatedObject := &corev1.ConfigMap{}
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, updatedObject)
+		assert.NoError(t, err)
+		assert.Equal(t, "value3", updatedObject.Data["key3"])
+	})
+	t.Run("update unstructured object", func(t *testing.T) {
+		i, r := mockSetup(controller)
+
+		object := &unstructured.Unstructured{}
+		object.SetAPIVersion("test/v1")
+		object.SetKind("Test")
+		object.SetName("test-object")
+		object.SetNamespace("test")
+		err := unstructured.SetNestedField(object.Object, "value1", "spec", "key1")
+		assert.NoError(t, err)
+
+		_, err = operation.Create(operation.Conf{Instance: i, Reconcile: r, Object: object.DeepCopy()})
+		assert.NoError(t, err)
+
+		_, err = operation.CreateOrUpdate(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    object,
+			MaybeUpdateFunc: func(existing interfaces.Object, _ interfaces.Object) (bool, error) {
+				u, ok := existing.(*unstructured.Unstructured)
+				if !ok {
+					return false, errors.New("failed to assert the existing object")
+				}
+				value, _, _ := unstructured.NestedString(u.Object, "spec", "key1")
+				if value != "value1" {
+					return false, errors.New("existing object is not fetched")
+				}
+				return false, nil
+			},
+		})
+		assert.NoError(t, err)
+	})
+}
+
+// pendingDeleteClient records the options of the delete requests.
+// If pending is set, it keeps the object around with the deletion
+// timestamp set, like API Server does for the objects with
+// finalizers, until the object is deleted with the embedded client.
+type pendingDeleteClient struct {
+	client.Client
+	pending bool
+	deletes int
+	opts    client.DeleteOptions
+}
+
+func (c *pendingDeleteClient) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
+	c.deletes++
+	c.opts = client.DeleteOptions{}
+	c.opts.ApplyOptions(opts)
+	if !c.pending {
+		return c.Client.Delete(ctx, obj, opts...)
+	}
+
+	o := obj.DeepCopyObject().(interfaces.Object)
+	err := c.Client.Get(ctx, types.NamespacedName{Name: o.GetName(), Namespace: o.GetNamespace()}, o)
+	if err != nil {
+		return err
+	}
+	now := metav1.Now()
+	o.SetDeletionTimestamp(&now)
+	return c.Client.Update(ctx, o)
+}
+
+func TestDeleteOptions(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	deleteSetup := func(pending bool) (*mocks.MockObject, *mocks.MockReconcile, *pendingDeleteClient) {
+		i, r := mockSetup(controller)
+		c := &pendingDeleteClient{Client: r.GetClient(), pending: pending}
+		wrapped := mocks.NewMockReconcile(controller)
+		wrapped.EXPECT().GetClient().Return(c).AnyTimes()
+		wrapped.EXPECT().GetScheme().Return(r.GetScheme()).AnyTimes()
+		return i, wrapped, c
+	}
+
+	t.Run("delete configmap with options", func(t *testing.T) {
+		i, r, c := deleteSetup(false)
+		gracePeriod := int64(10)
+		uid := types.UID("test-uid")
+
+		_, err := operation.Delete(operation.Conf{
+			Instance:           i,
+			Reconcile:          r,
+			Object:             &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			PropagationPolicy:  metav1.DeletePropagationForeground,
+			Preconditions:      &metav1.Preconditions{UID: &uid},
+			GracePeriodSeconds: &gracePeriod,
+		})
+		assert.NoError(t, err)
+
+		assert.Equal(t, metav1.DeletePropagationForeground, *c.opts.PropagationPolicy)
+		assert.Equal(t, &uid, c.opts.Preconditions.UID)
+		assert.Equal(t, gracePeriod, *c.opts.GracePeriodSeconds)
+	})
+	t.Run("delete configmap without options", func(t *testing.T) {
+		i, r, c := deleteSetup(false)
+
+		_, err := operation.Delete(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+		})
+		assert.NoError(t, err)
+
+		assert.Nil(t, c.opts.PropagationPolicy)
+		assert.Nil(t, c.opts.Preconditions)
+		assert.Nil(t, c.opts.GracePeriodSeconds)
+	})
+	t.Run("wait for deletion", func(t *testing.T) {
+		i, mr, c := deleteSetup(true)
+		r := mocks.NewRecorderReconcile(mr, 10)
+		hookCalls := 0
+		conf := operation.Conf{
+			Instance:             i,
+			Reconcile:            r,
+			Object:               &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			WaitForDeletion:      true,
+			DeletionPollInterval: time.Second,
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				hookCalls++
+				return reconcile.Result{}, nil
+			},
+		}
+
+		result, err := operation.Delete(conf)
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{RequeueAfter: time.Second}, result)
+		assert.Equal(t, 0, hookCalls)
+
+		// The delete request is not sent again while the object is
+		// being deleted.
+		result, err = operation.Delete(conf)
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{RequeueAfter: time.Second}, result)
+		assert.Equal(t, 0, hookCalls)
+		assert.Equal(t, 1, c.deletes)
+
+		err = c.Client.Delete(context.TODO(), &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}})
+		assert.NoError(t, err)
+
+		result, err = operation.Delete(conf)
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+		assert.Equal(t, 1, hookCalls)
+		assert.Equal(t, 1, c.deletes)
+		assert.Equal(t, []string{"Normal Deleted Deleted ConfigMap test-existing-configmap"}, r.Events())
+	})
+	t.Run("wait for deletion uses default interval", func(t *testing.T) {
+		i, r, _ := deleteSetup(true)
+
+		result, err := operation.Delete(operation.Conf{
+			Instance:        i,
+			Reconcile:       r,
+			Object:          &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			WaitForDeletion: true,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{RequeueAfter: operation.DefaultDeletionPollInterval}, result)
+	})
+}
@@ -0,0 +1,155 @@
+package operation
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UpdateStrategy defines how Update sends the changes made by
+// MaybeUpdateFunc to API Server.
+type UpdateStrategy int
+
+const (
+	// FullUpdate sends the whole updated object. The update fails
+	// if the object changed in cluster after it was fetched. This is
+	// the default strategy.
+	FullUpdate UpdateStrategy = iota
+	// MergePatch sends a JSON merge patch of the fields changed by
+	// MaybeUpdateFunc. Lists are replaced as a whole.
+	MergePatch
+	// StrategicMergePatch sends a strategic merge patch of the fields
+	// changed by MaybeUpdateFunc. Lists are merged using the patch
+	// strategy of the field. It only works for the built-in types
+	// since custom resources do not have the patch strategies.
+	StrategicMergePatch
+	// JSONPatch sends a JSON patch with the operations required to
+	// turn the fetched object into the updated one. Lists are
+	// replaced as a whole.
+	JSONPatch
+)
+
+// jsonPatchOperation is a single operation of JSON patch as defined
+// in RFC 6902.
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// MarshalJSON encodes the operation. The value is required by all the
+// operations used except remove, even if it is null, so it is omitted
+// only for remove.
+func (o jsonPatchOperation) MarshalJSON() ([]byte, error) {
+	if o.Op == "remove" {
+		return json.Marshal(struct {
+			Op   string `json:"op"`
+			Path string `json:"path"`
+		}{Op: o.Op, Path: o.Path})
+	}
+
+	type plain jsonPatchOperation
+	return json.Marshal(plain(o))
+}
+
+// patchFor returns the patch which turns the original object into the
+// modified one using the strategy passed.
+func patchFor(strategy UpdateStrategy, original, modified runtime.Object) (client.Patch, error) {
+	if strategy == MergePatch {
+		return client.MergeFrom(original), nil
+	}
+
+	originalJSON, err := json.Marshal(original)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode the original object")
+	}
+
+	modifiedJSON, err := json.Marshal(modified)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode the modified object")
+	}
+
+	switch strategy {
+	case StrategicMergePatch:
+		data, err := strategicpatch.CreateTwoWayMergePatch(originalJSON, modifiedJSON, modified)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create strategic merge patch")
+		}
+		return client.ConstantPatch(types.StrategicMergePatchType, data), nil
+	case JSONPatch:
+		var o, m interface{}
+		if err = json.Unmarshal(originalJSON, &o); err != nil {
+			return nil, errors.Wrap(err, "failed to decode the original object")
+		}
+		if err = json.Unmarshal(modifiedJSON, &m); err != nil {
+			return nil, errors.Wrap(err, "failed to decode the modified object")
+		}
+		data, err := json.Marshal(diff("", o, m, []jsonPatchOperation{}))
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode json patch")
+		}
+		return client.ConstantPatch(types.JSONPatchType, data), nil
+	default:
+		return nil, errors.Errorf("unknown update strategy %d", strategy)
+	}
+}
+
+// diff appends the JSON patch operations which turn o into m to ops.
+// Objects are compared key by key while any other values, including
+// lists, are replaced when different.
+func diff(path string, o, m interface{}, ops []jsonPatchOperation) []jsonPatchOperation {
+	om, ok := o.(map[string]interface{})
+	mm, mok := m.(map[string]interface{})
+	if !ok || !mok {
+		if !equalJSON(o, m) {
+			ops = append(ops, jsonPatchOperation{Op: "replace", Path: path, Value: m})
+		}
+		return ops
+	}
+
+	keys := make([]string, 0, len(om)+len(mm))
+	for k := range om {
+		keys = append(keys, k)
+	}
+	for k := range mm {
+		if _, ok := om[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		p := path + "/" + escapePointer(k)
+		ov, inOriginal := om[k]
+		mv, inModified := mm[k]
+		switch {
+		case !inModified:
+			ops = append(ops, jsonPatchOperation{Op: "remove", Path: p})
+		case !inOriginal:
+			ops = append(ops, jsonPatchOperation{Op: "add", Path: p, Value: mv})
+		default:
+			ops = diff(p, ov, mv, ops)
+		}
+	}
+
+	return ops
+}
+
+// equalJSON compares two decoded JSON values.
+func equalJSON(a, b interface{}) bool {
+	aj, aerr := json.Marshal(a)
+	bj, berr := json.Marshal(b)
+	return aerr == nil && berr == nil && string(aj) == string(bj)
+}
+
+// escapePointer escapes the key to be used as a token in JSON
+// pointer as defined in RFC 6901.
+func escapePointer(k string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(k)
+}
@@ -0,0 +1,110 @@
+package operation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// jsonPatchClient returns a copy of existing for every object fetched
+// and records the data of the patch requests.
+type jsonPatchClient struct {
+	client.Client
+	existing *unstructured.Unstructured
+	data     []byte
+}
+
+func (c *jsonPatchClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	c.existing.DeepCopyInto(obj.(*unstructured.Unstructured))
+	return nil
+}
+
+func (c *jsonPatchClient) Patch(ctx context.Context, obj runtime.Object, patch client.Patch, opts ...client.PatchOption) error {
+	var err error
+	c.data, err = patch.Data(obj)
+	return err
+}
+
+func TestJSONPatch(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	object := func(spec map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "test.io/v1",
+			"kind":       "Test",
+			"metadata":   map[string]interface{}{"name": "test", "namespace": "test"},
+			"spec":       spec,
+		}}
+	}
+	setSpec := func(spec map[string]interface{}) operation.MaybeUpdateFunc {
+		return func(existing interfaces.Object, _ interfaces.Object) (bool, error) {
+			existing.(*unstructured.Unstructured).Object["spec"] = spec
+			return true, nil
+		}
+	}
+	update := func(t *testing.T, existing, spec map[string]interface{}) string {
+		i, fr := mockSetup(controller)
+		c := &jsonPatchClient{Client: fr.GetClient(), existing: object(existing)}
+		r := mocks.NewMockReconcile(controller)
+		r.EXPECT().GetClient().Return(c).AnyTimes()
+		r.EXPECT().GetScheme().Return(fr.GetScheme()).AnyTimes()
+
+		_, err := operation.Update(operation.Conf{
+			Instance:        i,
+			Reconcile:       r,
+			Object:          object(nil),
+			MaybeUpdateFunc: setSpec(spec),
+			UpdateStrategy:  operation.JSONPatch,
+		})
+		assert.NoError(t, err)
+		return string(c.data)
+	}
+
+	t.Run("add values", func(t *testing.T) {
+		data := update(t, map[string]interface{}{}, map[string]interface{}{
+			"a": nil,
+			"b": int64(0),
+			"c": false,
+			"d": map[string]interface{}{"key": "value"},
+		})
+		assert.JSONEq(t, `[
+			{"op": "add", "path": "/spec/a", "value": null},
+			{"op": "add", "path": "/spec/b", "value": 0},
+			{"op": "add", "path": "/spec/c", "value": false},
+			{"op": "add", "path": "/spec/d", "value": {"key": "value"}}
+		]`, data)
+	})
+	t.Run("replace values", func(t *testing.T) {
+		data := update(t, map[string]interface{}{
+			"a": "value",
+			"b": int64(1),
+			"c": true,
+			"d": "value",
+		}, map[string]interface{}{
+			"a": nil,
+			"b": int64(0),
+			"c": false,
+			"d": map[string]interface{}{},
+		})
+		assert.JSONEq(t, `[
+			{"op": "replace", "path": "/spec/a", "value": null},
+			{"op": "replace", "path": "/spec/b", "value": 0},
+			{"op": "replace", "path": "/spec/c", "value": false},
+			{"op": "replace", "path": "/spec/d", "value": {}}
+		]`, data)
+	})
+	t.Run("remove value", func(t *testing.T) {
+		data := update(t, map[string]interface{}{"a": "value"}, map[string]interface{}{})
+		assert.JSONEq(t, `[{"op": "remove", "path": "/spec/a"}]`, data)
+	})
+}
@@ -0,0 +1,267 @@
+package operation
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Redacted replaces the values of the redacted fields in diffs.
+const Redacted = "<redacted>"
+
+// DryRunMode defines if and how the operation functions skip the
+// changes to the cluster.
+type DryRunMode int
+
+const (
+	// NoDryRun makes the changes to the cluster. This is the default
+	// mode.
+	NoDryRun DryRunMode = iota
+	// ServerDryRun sends the requests to API Server with DryRun=All,
+	// so they are validated and admitted but not persisted.
+	ServerDryRun
+	// ClientDryRun skips the requests which change the cluster. The
+	// objects are still fetched to find out what would change.
+	ClientDryRun
+)
+
+// Action is the kind of change an operation makes to an object.
+type Action string
+
+const (
+	// ActionCreate means the object is created.
+	ActionCreate Action = "create"
+	// ActionUpdate means the object is updated.
+	ActionUpdate Action = "update"
+	// ActionDelete means the object is deleted.
+	ActionDelete Action = "delete"
+	// ActionNoop means the object is left as it is.
+	ActionNoop Action = "noop"
+)
+
+// FieldDiff is the change of a single field of an object. Path is the
+// JSON pointer of the field. Old is nil for the fields being added
+// and New is nil for the fields being removed.
+type FieldDiff struct {
+	Path string
+	Old  interface{}
+	New  interface{}
+}
+
+// PlanEntry describes the change an operation made, or would make
+// in dry-run mode, to an object.
+type PlanEntry struct {
+	Action
+	schema.GroupVersionKind
+	Name      string
+	Namespace string
+	// Diff lists the fields changed by create and update. It is
+	// empty for delete and noop.
+	Diff []FieldDiff
+}
+
+// Plan collects the entries recorded by the operation functions. The
+// same Plan can be passed to all the operations of a reconcile to get
+// the complete list of changes.
+type Plan struct {
+	Entries []PlanEntry
+}
+
+// record adds the entry for the Object in Conf to Plan, if set.
+func record(c Conf, action Action, diff []FieldDiff) {
+	if c.Plan == nil {
+		return
+	}
+
+	c.Plan.Entries = append(c.Plan.Entries, PlanEntry{
+		Action:           action,
+		GroupVersionKind: gvkFor(c),
+		Name:             c.Object.GetName(),
+		Namespace:        c.Object.GetNamespace(),
+		Diff:             Redact(diff, c.RedactPaths...),
+	})
+}
+
+// recordChange adds the entry for the Object in Conf to Plan, if set,
+// with the diff from original to modified, either of which can be
+// nil.
+func recordChange(c Conf, action Action, original, modified runtime.Object) error {
+	if c.Plan == nil {
+		return nil
+	}
+
+	diff, err := Diff(original, modified)
+	if err != nil {
+		return errors.Wrap(err, "failed to compute the diff of the object")
+	}
+
+	record(c, action, diff)
+	return nil
+}
+
+// gvkFor returns the GroupVersionKind of the Object in Conf. The
+// typed objects usually do not have their TypeMeta set, so it is
+// looked up in the scheme first.
+func gvkFor(c Conf) schema.GroupVersionKind {
+	gvk, err := apiutil.GVKForObject(c.Object, c.Reconcile.GetScheme())
+	if err != nil {
+		return c.Object.GetObjectKind().GroupVersionKind()
+	}
+	return gvk
+}
+
+// Diff returns the changes of the leaf fields from original to
+// modified, as per their JSON representation. Lists are compared as a
+// whole. Either of the objects can be nil.
+func Diff(original, modified runtime.Object) ([]FieldDiff, error) {
+	o, err := decode(original)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the original object")
+	}
+
+	m, err := decode(modified)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the modified object")
+	}
+
+	return changes("", o, m, nil), nil
+}
+
+// Redact returns a copy of the diff with the values of the fields at
+// the JSON pointers passed, and the fields under them, replaced with
+// Redacted.
+func Redact(diff []FieldDiff, paths ...string) []FieldDiff {
+	if len(paths) == 0 {
+		return diff
+	}
+
+	redacted := make([]FieldDiff, len(diff))
+	for i, d := range diff {
+		redacted[i] = d
+		for _, p := range paths {
+			if d.Path != p && !strings.HasPrefix(d.Path, p+"/") {
+				continue
+			}
+			if d.Old != nil {
+				redacted[i].Old = Redacted
+			}
+			if d.New != nil {
+				redacted[i].New = Redacted
+			}
+			break
+		}
+	}
+
+	return redacted
+}
+
+// decode returns the JSON representation of the object as generic
+// value. Nil object is decoded as nil.
+func decode(obj runtime.Object) (interface{}, error) {
+	if obj == nil {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	var v interface{}
+	err = json.Unmarshal(data, &v)
+	return v, err
+}
+
+// changes appends the changes from o to m to diffs. A missing value
+// is treated as nil, so only the objects set on one side are walked
+// into.
+func changes(path string, o, m interface{}, diffs []FieldDiff) []FieldDiff {
+	om, ok := o.(map[string]interface{})
+	mm, mok := m.(map[string]interface{})
+	switch {
+	case ok && m == nil:
+		mok = true
+	case mok && o == nil:
+		ok = true
+	}
+	if !ok || !mok {
+		if !equalJSON(o, m) {
+			diffs = append(diffs, FieldDiff{Path: path, Old: o, New: m})
+		}
+		return diffs
+	}
+
+	keys := make([]string, 0, len(om)+len(mm))
+	for k := range om {
+		keys = append(keys, k)
+	}
+	for k := range mm {
+		if _, ok := om[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		diffs = changes(path+"/"+escapePointer(k), om[k], mm[k], diffs)
+	}
+
+	return diffs
+}
+
+// merged returns the existing object with the fields set in the
+// object merged into it, which is the closest to what apply does
+// without API Server. Null values in the object are unset fields, so
+// they are dropped instead of removing the fields.
+func merged(existing, object runtime.Object) (runtime.Object, error) {
+	existingJSON, err := json.Marshal(existing)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode the existing object")
+	}
+
+	o, err := decode(object)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the object")
+	}
+
+	patch, err := json.Marshal(dropNulls(o))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to encode the object")
+	}
+
+	data, err := jsonpatch.MergePatch(existingJSON, patch)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to merge the object")
+	}
+
+	result := existing.DeepCopyObject()
+	if err = json.Unmarshal(data, result); err != nil {
+		return nil, errors.Wrap(err, "failed to decode the merged object")
+	}
+
+	return result, nil
+}
+
+// dropNulls returns a copy of the decoded JSON value without the keys
+// with null values in the objects, recursively.
+func dropNulls(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	result := make(map[string]interface{}, len(m))
+	for k, e := range m {
+		if e != nil {
+			result[k] = dropNulls(e)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,427 @@
+package operation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestDryRun(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	configMapKind := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	hookCalled := false
+	hook := func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+		hookCalled = true
+		return reconcile.Result{}, nil
+	}
+	updateData := func(existing interfaces.Object, new interfaces.Object) (bool, error) {
+		e := existing.(*corev1.ConfigMap)
+		n := new.(*corev1.ConfigMap)
+		if e.Data["key1"] == n.Data["key1"] {
+			return false, nil
+		}
+		e.Data["key1"] = n.Data["key1"]
+		return true, nil
+	}
+
+	for _, mode := range []operation.DryRunMode{operation.ServerDryRun, operation.ClientDryRun} {
+		t.Run("create configmap", func(t *testing.T) {
+			i, r := mockSetup(controller)
+			client := r.GetClient()
+			plan := &operation.Plan{}
+			hookCalled = false
+
+			_, err := operation.Create(operation.Conf{
+				Instance:  i,
+				Reconcile: r,
+				Object: &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"},
+					Data:       map[string]string{"key": "value"},
+				},
+				DryRun:          mode,
+				Plan:            plan,
+				AfterCreateFunc: hook,
+			})
+			assert.NoError(t, err)
+			assert.False(t, hookCalled)
+
+			err = client.Get(context.TODO(), types.NamespacedName{Name: "test-configmap", Namespace: "test"}, &corev1.ConfigMap{})
+			assert.True(t, kerrors.IsNotFound(err))
+
+			assert.Len(t, plan.Entries, 1)
+			assert.Equal(t, operation.ActionCreate, plan.Entries[0].Action)
+			assert.Equal(t, configMapKind, plan.Entries[0].GroupVersionKind)
+			assert.Equal(t, "test-configmap", plan.Entries[0].Name)
+			assert.Equal(t, "test", plan.Entries[0].Namespace)
+			assert.Contains(t, plan.Entries[0].Diff, operation.FieldDiff{Path: "/data/key", New: "value"})
+		})
+		t.Run("create or update configmap", func(t *testing.T) {
+			i, r := mockSetup(controller)
+			client := r.GetClient()
+			plan := &operation.Plan{}
+			hookCalled = false
+
+			_, err := operation.CreateOrUpdate(operation.Conf{
+				Instance:  i,
+				Reconcile: r,
+				Object: &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+					Data:       map[string]string{"key1": "new-value1"},
+				},
+				MaybeUpdateFunc: updateData,
+				DryRun:          mode,
+				Plan:            plan,
+				AfterUpdateFunc: hook,
+			})
+			assert.NoError(t, err)
+			assert.False(t, hookCalled)
+
+			existing := &corev1.ConfigMap{}
+			err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, existing)
+			assert.NoError(t, err)
+			assert.Equal(t, "value1", existing.Data["key1"])
+
+			assert.Equal(t, []operation.PlanEntry{{
+				Action:           operation.ActionUpdate,
+				GroupVersionKind: configMapKind,
+				Name:             "test-existing-configmap",
+				Namespace:        "test",
+				Diff:             []operation.FieldDiff{{Path: "/data/key1", Old: "value1", New: "new-value1"}},
+			}}, plan.Entries)
+		})
+		t.Run("update configmap which is up-to-date", func(t *testing.T) {
+			i, r := mockSetup(controller)
+			plan := &operation.Plan{}
+
+			_, err := operation.Update(operation.Conf{
+				Instance:  i,
+				Reconcile: r,
+				Object: &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+					Data:       map[string]string{"key1": "value1"},
+				},
+				MaybeUpdateFunc: updateData,
+				DryRun:          mode,
+				Plan:            plan,
+			})
+			assert.NoError(t, err)
+			assert.Len(t, plan.Entries, 1)
+			assert.Equal(t, operation.ActionNoop, plan.Entries[0].Action)
+			assert.Empty(t, plan.Entries[0].Diff)
+		})
+		t.Run("delete configmap which does not exist", func(t *testing.T) {
+			i, r := mockSetup(controller)
+			plan := &operation.Plan{}
+
+			_, err := operation.Delete(operation.Conf{
+				Instance:  i,
+				Reconcile: r,
+				Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}},
+				DryRun:    mode,
+				Plan:      plan,
+			})
+			assert.NoError(t, err)
+			assert.Len(t, plan.Entries, 1)
+			assert.Equal(t, operation.ActionNoop, plan.Entries[0].Action)
+		})
+	}
+
+	t.Run("create configmap which exists", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		plan := &operation.Plan{}
+
+		_, err := operation.Create(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			DryRun:    operation.ClientDryRun,
+			Plan:      plan,
+		})
+		assert.Error(t, err)
+		assert.Empty(t, plan.Entries)
+	})
+	// The fake client deletes the object even with DryRun=All, so
+	// only ClientDryRun is tested for delete.
+	t.Run("delete configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+		plan := &operation.Plan{}
+		hookCalled = false
+
+		_, err := operation.Delete(operation.Conf{
+			Instance:        i,
+			Reconcile:       r,
+			Object:          &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			DryRun:          operation.ClientDryRun,
+			Plan:            plan,
+			AfterDeleteFunc: hook,
+		})
+		assert.NoError(t, err)
+		assert.False(t, hookCalled)
+
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, &corev1.ConfigMap{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, []operation.PlanEntry{{
+			Action:           operation.ActionDelete,
+			GroupVersionKind: configMapKind,
+			Name:             "test-existing-configmap",
+			Namespace:        "test",
+		}}, plan.Entries)
+	})
+	t.Run("apply configmap", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+		plan := &operation.Plan{}
+
+		_, err := operation.CreateOrUpdate(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object: &corev1.ConfigMap{
+				TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+				ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"},
+				Data:       map[string]string{"key3": "value3"},
+			},
+			Apply:  true,
+			DryRun: operation.ClientDryRun,
+			Plan:   plan,
+		})
+		assert.NoError(t, err)
+
+		existing := &corev1.ConfigMap{}
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, existing)
+		assert.NoError(t, err)
+		assert.NotContains(t, existing.Data, "key3")
+
+		assert.Len(t, plan.Entries, 1)
+		assert.Equal(t, operation.ActionUpdate, plan.Entries[0].Action)
+		assert.Contains(t, plan.Entries[0].Diff, operation.FieldDiff{Path: "/data/key3", New: "value3"})
+	})
+}
+
+func TestPlan(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	i, r := mockSetup(controller)
+	plan := &operation.Plan{}
+
+	_, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:  i,
+		Reconcile: r,
+		Object: &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"},
+			Data:       map[string]string{"key": "value"},
+		},
+		Plan: plan,
+	})
+	assert.NoError(t, err)
+
+	_, err = operation.Delete(operation.Conf{
+		Instance:  i,
+		Reconcile: r,
+		Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}},
+		Plan:      plan,
+	})
+	assert.NoError(t, err)
+
+	_, err = operation.Delete(operation.Conf{
+		Instance:  i,
+		Reconcile: r,
+		Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap", Namespace: "test"}},
+		Plan:      plan,
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, plan.Entries, 3)
+	assert.Equal(t, operation.ActionCreate, plan.Entries[0].Action)
+	assert.Equal(t, operation.ActionDelete, plan.Entries[1].Action)
+	assert.Equal(t, operation.ActionNoop, plan.Entries[2].Action)
+}
+
+func TestRedact(t *testing.T) {
+	diff := []operation.FieldDiff{
+		{Path: "/data/key", Old: "old", New: "new"},
+		{Path: "/data", New: map[string]interface{}{"key": "value"}},
+		{Path: "/dataKey", Old: "old"},
+		{Path: "/metadata/labels/key", New: "value"},
+	}
+
+	assert.Equal(t, diff, operation.Redact(diff))
+	assert.Equal(t, []operation.FieldDiff{
+		{Path: "/data/key", Old: operation.Redacted, New: operation.Redacted},
+		{Path: "/data", New: operation.Redacted},
+		{Path: "/dataKey", Old: "old"},
+		{Path: "/metadata/labels/key", New: "value"},
+	}, operation.Redact(diff, "/data"))
+	assert.Equal(t, "old", diff[0].Old)
+}
+
+// testLogger records the key and value pairs passed to Info.
+type testLogger struct {
+	logr.Logger
+	values []interface{}
+}
+
+func (l *testLogger) V(int) logr.InfoLogger { return l }
+
+func (l *testLogger) Enabled() bool { return true }
+
+func (l *testLogger) Info(_ string, keysAndValues ...interface{}) {
+	l.values = append(l.values, keysAndValues...)
+}
+
+func TestUpdateDiff(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("update configmap with diff function", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+		plan := &operation.Plan{}
+		logger := &testLogger{}
+		var hookDiff []operation.FieldDiff
+
+		_, err := operation.Update(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			MaybeUpdateFunc: func(interfaces.Object, interfaces.Object) (bool, error) {
+				return false, nil
+			},
+			MaybeUpdateWithDiffFunc: func(existing interfaces.Object, _ interfaces.Object) ([]operation.FieldDiff, error) {
+				existing.(*corev1.ConfigMap).Data["key1"] = "new-value1"
+				return []operation.FieldDiff{{Path: "/data/key1", Old: "value1", New: "new-value1"}}, nil
+			},
+			DiffHook: func(_ context.Context, _ interfaces.Object, _ interfaces.Object, diff []operation.FieldDiff) {
+				hookDiff = diff
+			},
+			Logger:      logger,
+			Plan:        plan,
+			RedactPaths: []string{"/data"},
+		})
+		assert.NoError(t, err)
+
+		cm := &corev1.ConfigMap{}
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, cm)
+		assert.NoError(t, err)
+		assert.Equal(t, "new-value1", cm.Data["key1"])
+
+		redacted := []operation.FieldDiff{{Path: "/data/key1", Old: operation.Redacted, New: operation.Redacted}}
+		assert.Equal(t, redacted, hookDiff)
+		assert.Equal(t, redacted, plan.Entries[0].Diff)
+		assert.Contains(t, logger.values, redacted)
+	})
+	t.Run("update configmap with empty diff", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		hookCalled := false
+
+		_, err := operation.Update(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			MaybeUpdateWithDiffFunc: func(interfaces.Object, interfaces.Object) ([]operation.FieldDiff, error) {
+				return nil, nil
+			},
+			DiffHook: func(context.Context, interfaces.Object, interfaces.Object, []operation.FieldDiff) {
+				hookCalled = true
+			},
+		})
+		assert.NoError(t, err)
+		assert.False(t, hookCalled)
+	})
+	t.Run("update configmap computes diff for maybe update function", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		var hookDiff []operation.FieldDiff
+
+		_, err := operation.Update(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			MaybeUpdateFunc: func(existing interfaces.Object, _ interfaces.Object) (bool, error) {
+				delete(existing.(*corev1.ConfigMap).Data, "key2")
+				return true, nil
+			},
+			DiffHook: func(_ context.Context, _ interfaces.Object, _ interfaces.Object, diff []operation.FieldDiff) {
+				hookDiff = diff
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []operation.FieldDiff{{Path: "/data/key2", Old: "value2"}}, hookDiff)
+	})
+	t.Run("update configmap returns diff", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		hookCalled := false
+
+		_, diff, err := operation.UpdateWithDiff(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			MaybeUpdateFunc: func(existing interfaces.Object, _ interfaces.Object) (bool, error) {
+				existing.(*corev1.ConfigMap).Data["key1"] = "new-value1"
+				return true, nil
+			},
+			DiffHook: func(context.Context, interfaces.Object, interfaces.Object, []operation.FieldDiff) {
+				hookCalled = true
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []operation.FieldDiff{{Path: "/data/key1", Old: "value1", New: "new-value1"}}, diff)
+		assert.True(t, hookCalled)
+	})
+	t.Run("update up-to-date configmap returns empty diff", func(t *testing.T) {
+		i, r := mockSetup(controller)
+
+		_, diff, err := operation.UpdateWithDiffWithContext(context.TODO(), operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			MaybeUpdateFunc: func(interfaces.Object, interfaces.Object) (bool, error) {
+				return false, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+	t.Run("update configmap records diff in plan", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+		plan := &operation.Plan{}
+
+		_, err := operation.Update(operation.Conf{
+			Instance:  i,
+			Reconcile: r,
+			Object:    &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test"}},
+			MaybeUpdateFunc: func(existing interfaces.Object, _ interfaces.Object) (bool, error) {
+				existing.(*corev1.ConfigMap).Data["key1"] = "new-value1"
+				return true, nil
+			},
+			Plan: plan,
+		})
+		assert.NoError(t, err)
+
+		cm := &corev1.ConfigMap{}
+		err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, cm)
+		assert.NoError(t, err)
+		assert.Equal(t, "new-value1", cm.Data["key1"])
+
+		if assert.Len(t, plan.Entries, 1) {
+			assert.Equal(t, operation.ActionUpdate, plan.Entries[0].Action)
+			assert.Equal(t, []operation.FieldDiff{{Path: "/data/key1", Old: "value1", New: "new-value1"}}, plan.Entries[0].Diff)
+		}
+	})
+}
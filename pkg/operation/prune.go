@@ -0,0 +1,133 @@
+package operation
+
+import (
+	"context"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	"github.com/pkg/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// objectKey identifies an object across the kinds.
+type objectKey struct {
+	schema.GroupVersionKind
+	types.NamespacedName
+}
+
+// Prune deletes the children of the Instance which are not generated
+// anymore, for instance when a custom resource is scaled down from
+// three ConfigMaps to two. It lists the objects of the kinds in
+// PruneConf which have the labels generated by GenLabelsFunc and
+// deletes the ones which are owned by the Instance but are not in
+// Keep. Objects not owned by the Instance are never deleted.
+func Prune(c PruneConf) (reconcile.Result, error) {
+	return prune(context.Background(), c)
+}
+
+// PruneWithContext is same as Prune but uses the context passed for
+// the calls to API Server.
+func PruneWithContext(ctx context.Context, c PruneConf) (reconcile.Result, error) {
+	return prune(ctx, c)
+}
+
+func prune(ctx context.Context, c PruneConf) (reconcile.Result, error) {
+	cl := c.Reconcile.GetClient()
+
+	// Conf used for the calls to API Server and deleting the objects.
+	dc := Conf{
+		Instance:  c.Instance,
+		Reconcile: c.Reconcile,
+		Timeout:   c.Timeout,
+		DryRun:    c.DryRun,
+		Plan:      c.Plan,
+	}
+
+	keep := make(map[objectKey]bool, len(c.Keep))
+	for _, o := range c.Keep {
+		gvk, err := apiutil.GVKForObject(o, c.Reconcile.GetScheme())
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to get the kind of the object to keep")
+		}
+		keep[objectKey{gvk, types.NamespacedName{Name: o.GetName(), Namespace: o.GetNamespace()}}] = true
+	}
+
+	opts := []client.ListOption{client.InNamespace(c.Namespace)}
+	if c.GenLabelsFunc != nil {
+		labels, err := c.GenLabelsFunc(c.Instance)
+		if err != nil {
+			return reconcile.Result{}, errors.Wrap(err, "failed to generate labels")
+		}
+		opts = append(opts, client.MatchingLabels(labels))
+	}
+
+	var r reconcile.Result
+	for _, gvk := range c.GroupVersionKinds {
+		list := newList(c.Reconcile.GetScheme(), gvk)
+
+		cctx, cancel := CallContext(ctx, dc.Timeout)
+		err := cl.List(cctx, list, opts...)
+		cancel()
+		if err != nil {
+			return r, errors.Wrapf(err, "failed to list the objects of kind %s", gvk.Kind)
+		}
+
+		items, err := apimeta.ExtractList(list)
+		if err != nil {
+			return r, errors.Wrapf(err, "failed to extract the objects of kind %s", gvk.Kind)
+		}
+
+		for _, item := range items {
+			o, ok := item.(interfaces.Object)
+			if !ok {
+				return r, errors.Errorf("object of kind %s does not implement Object", gvk.Kind)
+			}
+			if keep[objectKey{gvk, types.NamespacedName{Name: o.GetName(), Namespace: o.GetNamespace()}}] || !ownedBy(o, c.Instance) {
+				continue
+			}
+
+			dc.Object = o
+			dr, err := delete(ctx, dc)
+			r = MergeResults(r, dr)
+			if err != nil {
+				return r, errors.Wrapf(err, "failed to prune %s %s", gvk.Kind, o.GetName())
+			}
+		}
+	}
+
+	return r, nil
+}
+
+// newList returns an empty list of the kind passed. The list is of the
+// type registered in the scheme, if any, or else unstructured.
+func newList(s *runtime.Scheme, gvk schema.GroupVersionKind) runtime.Object {
+	listGVK := gvk.GroupVersion().WithKind(gvk.Kind + "List")
+	if list, err := s.New(listGVK); err == nil {
+		return list
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(listGVK)
+	return list
+}
+
+// ownedBy reports if the object has owner reference to the owner.
+func ownedBy(o interfaces.Object, owner interfaces.Object) bool {
+	if owner.GetUID() == "" {
+		return false
+	}
+
+	for _, ref := range o.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package operation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/golang/mock/gomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func pruneSetup(t *testing.T, c client.Client) {
+	ownerReferences := []metav1.OwnerReference{{
+		APIVersion: "test/v1",
+		Kind:       "Test",
+		Name:       "test",
+		UID:        types.UID("199bd7a8-b72a-4411-b55e-91096769e58f"),
+	}}
+	labels := map[string]string{"app": "test"}
+
+	for _, cm := range []*corev1.ConfigMap{
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap-1", Namespace: "test", Labels: labels, OwnerReferences: ownerReferences}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap-2", Namespace: "test", Labels: labels, OwnerReferences: ownerReferences}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap-not-owned", Namespace: "test", Labels: labels}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap-other-labels", Namespace: "test", OwnerReferences: ownerReferences}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap-other-namespace", Namespace: "other", Labels: labels, OwnerReferences: ownerReferences}},
+	} {
+		assert.NoError(t, c.Create(context.TODO(), cm))
+	}
+}
+
+func exists(c client.Client, name string, namespace string) bool {
+	err := c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: namespace}, &corev1.ConfigMap{})
+	return !kerrors.IsNotFound(err)
+}
+
+func TestPrune(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	configMapKind := schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}
+	genLabels := func(interfaces.Object) (map[string]string, error) {
+		return map[string]string{"app": "test"}, nil
+	}
+
+	t.Run("prune configmaps", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+		pruneSetup(t, client)
+		plan := &operation.Plan{}
+
+		_, err := operation.Prune(operation.PruneConf{
+			Instance:          i,
+			Reconcile:         r,
+			GroupVersionKinds: []schema.GroupVersionKind{configMapKind},
+			Namespace:         "test",
+			GenLabelsFunc:     genLabels,
+			Keep: []interfaces.Object{
+				&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-configmap-1", Namespace: "test"}},
+			},
+			Plan: plan,
+		})
+		assert.NoError(t, err)
+
+		assert.True(t, exists(client, "test-configmap-1", "test"))
+		assert.False(t, exists(client, "test-configmap-2", "test"))
+		assert.True(t, exists(client, "test-configmap-not-owned", "test"))
+		assert.True(t, exists(client, "test-configmap-other-labels", "test"))
+		assert.True(t, exists(client, "test-configmap-other-namespace", "other"))
+		assert.True(t, exists(client, "test-existing-configmap", "test"))
+
+		assert.Equal(t, []operation.PlanEntry{{
+			Action:           operation.ActionDelete,
+			GroupVersionKind: configMapKind,
+			Name:             "test-configmap-2",
+			Namespace:        "test",
+		}}, plan.Entries)
+	})
+	t.Run("prune configmaps in all namespaces", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+		pruneSetup(t, client)
+
+		_, err := operation.Prune(operation.PruneConf{
+			Instance:          i,
+			Reconcile:         r,
+			GroupVersionKinds: []schema.GroupVersionKind{configMapKind},
+			GenLabelsFunc:     genLabels,
+		})
+		assert.NoError(t, err)
+
+		assert.False(t, exists(client, "test-configmap-1", "test"))
+		assert.False(t, exists(client, "test-configmap-2", "test"))
+		assert.True(t, exists(client, "test-configmap-not-owned", "test"))
+		assert.False(t, exists(client, "test-configmap-other-namespace", "other"))
+	})
+	t.Run("prune configmaps in dry-run mode", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		client := r.GetClient()
+		pruneSetup(t, client)
+		plan := &operation.Plan{}
+
+		_, err := operation.Prune(operation.PruneConf{
+			Instance:          i,
+			Reconcile:         r,
+			GroupVersionKinds: []schema.GroupVersionKind{configMapKind},
+			Namespace:         "test",
+			GenLabelsFunc:     genLabels,
+			DryRun:            operation.ClientDryRun,
+			Plan:              plan,
+		})
+		assert.NoError(t, err)
+
+		assert.True(t, exists(client, "test-configmap-1", "test"))
+		assert.True(t, exists(client, "test-configmap-2", "test"))
+		assert.Len(t, plan.Entries, 2)
+	})
+	t.Run("generate labels fails", func(t *testing.T) {
+		i, r := mockSetup(controller)
+
+		_, err := operation.Prune(operation.PruneConf{
+			Instance:          i,
+			Reconcile:         r,
+			GroupVersionKinds: []schema.GroupVersionKind{configMapKind},
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,56 @@
+package operation
+
+import (
+	"context"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Recreate deletes the in-cluster version of the Object and creates
+// the Object again. It is used when a field which is immutable, see
+// ImmutableFieldError, is changed. The delete request uses the
+// PropagationPolicy from Conf, so set it to Background for the
+// objects whose dependents are orphaned by default, like Job. If the
+// old object is still around when the new one is being created, it
+// asks for requeue instead of failing. AfterDelete hooks are not
+// called, only the AfterCreate hooks.
+func Recreate(c Conf) (reconcile.Result, error) {
+	return recreate(context.Background(), c)
+}
+
+// RecreateWithContext is same as Recreate but uses the context passed
+// for the calls to API Server and the hooks.
+func RecreateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
+	return recreate(ctx, c)
+}
+
+func recreate(ctx context.Context, c Conf) (reconcile.Result, error) {
+	dc := c
+	dc.Object = c.Object.DeepCopyObject().(interfaces.Object)
+	dc.WaitForDeletion = false
+	dc.AfterDeleteFunc = nil
+	dc.AfterDeleteWithContextFunc = nil
+	dc.AfterDeleteHooks = nil
+	_, err := delete(ctx, dc)
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to delete the existing object")
+	}
+
+	// Creation in the failed attempt could have populated the Object,
+	// reset it before creating.
+	c.Object.SetResourceVersion("")
+
+	result, err := create(ctx, c, true)
+	if kerrors.IsAlreadyExists(errors.Cause(err)) {
+		return reconcile.Result{Requeue: true}, nil
+	}
+	if err != nil {
+		return result, errors.Wrap(err, "failed to recreate the object")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,88 @@
+package operation_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestRecreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	setup := func(pending bool) (*mocks.MockObject, *mocks.MockReconcile, *pendingDeleteClient) {
+		i, fr := mockSetup(controller)
+		c := &pendingDeleteClient{Client: fr.GetClient(), pending: pending}
+		r := mocks.NewMockReconcile(controller)
+		r.EXPECT().GetClient().Return(c).AnyTimes()
+		r.EXPECT().GetScheme().Return(fr.GetScheme()).AnyTimes()
+		return i, r, c
+	}
+	object := func() *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-configmap", Namespace: "test", ResourceVersion: "1"},
+			Data:       map[string]string{"key": "recreated"},
+		}
+	}
+
+	t.Run("recreate configmap", func(t *testing.T) {
+		i, r, c := setup(false)
+		var hooks []string
+
+		result, err := operation.RecreateWithContext(context.TODO(), operation.Conf{
+			Instance:          i,
+			Reconcile:         r,
+			Object:            object(),
+			PropagationPolicy: metav1.DeletePropagationBackground,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				hooks = append(hooks, "create")
+				return reconcile.Result{}, nil
+			},
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				hooks = append(hooks, "delete")
+				return reconcile.Result{}, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+		assert.Equal(t, []string{"create"}, hooks)
+		assert.Equal(t, 1, c.deletes)
+		if assert.NotNil(t, c.opts.PropagationPolicy) {
+			assert.Equal(t, metav1.DeletePropagationBackground, *c.opts.PropagationPolicy)
+		}
+
+		cm := &corev1.ConfigMap{}
+		err = c.Get(context.TODO(), types.NamespacedName{Name: "test-existing-configmap", Namespace: "test"}, cm)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"key": "recreated"}, cm.Data)
+	})
+	t.Run("recreate configmap which does not exist", func(t *testing.T) {
+		i, r, c := setup(false)
+		o := object()
+		o.Name = "test-configmap"
+
+		result, err := operation.Recreate(operation.Conf{Instance: i, Reconcile: r, Object: o})
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+
+		err = c.Get(context.TODO(), types.NamespacedName{Name: "test-configmap", Namespace: "test"}, &corev1.ConfigMap{})
+		assert.NoError(t, err)
+	})
+	t.Run("requeue while old configmap is being deleted", func(t *testing.T) {
+		i, r, _ := setup(true)
+
+		result, err := operation.Recreate(operation.Conf{Instance: i, Reconcile: r, Object: object()})
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{Requeue: true}, result)
+	})
+}
@@ -1,8 +1,16 @@
 package operation
 
 import (
+	"context"
+	"time"
+
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
 
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -14,6 +22,12 @@ import (
 // comparison.
 type MaybeUpdateFunc func(interfaces.Object, interfaces.Object) (bool, error)
 
+// MaybeUpdateWithDiffFunc is the variant of MaybeUpdateFunc which
+// reports what it changed. The function is supposed to update the
+// first argument and return the list of the fields changed. An empty
+// list means update is not required.
+type MaybeUpdateWithDiffFunc func(interfaces.Object, interfaces.Object) ([]FieldDiff, error)
+
 // HookFunc is the function type which is used by various hooks. The
 // function of this type can be used to hook custom logic in
 // pre-defined operations.
@@ -28,6 +42,24 @@ type AfterUpdateFunc HookFunc
 // AfterDeleteFunc is the hook called after deleting the object.
 type AfterDeleteFunc HookFunc
 
+// HookWithContextFunc is the function type which is used by the
+// context-aware hooks. It receives the context passed to the
+// operation function so that the hook can honour its cancellation and
+// use the values it carries.
+type HookWithContextFunc func(context.Context, interfaces.Object, interfaces.Reconcile) (reconcile.Result, error)
+
+// AfterCreateWithContextFunc is the context-aware hook called after
+// creating the object.
+type AfterCreateWithContextFunc HookWithContextFunc
+
+// AfterUpdateWithContextFunc is the context-aware hook called after
+// updating the object.
+type AfterUpdateWithContextFunc HookWithContextFunc
+
+// AfterDeleteWithContextFunc is the context-aware hook called after
+// deleting the object.
+type AfterDeleteWithContextFunc HookWithContextFunc
+
 // Conf is the struct used by all Operation functions. This can be
 // used to pass various parameters which can be used by the functions.
 type Conf struct {
@@ -41,8 +73,9 @@ type Conf struct {
 	// performed.
 	Object interfaces.Object
 	// ExistingObject is the pointer to the empty struct of Object
-	// which is used to fetch the existing object from cluster
-	// TODO: Figure out a way to avoid this
+	// which is used to fetch the existing object from cluster. It is
+	// optional, if nil an empty object of the same type as Object is
+	// created.
 	ExistingObject interfaces.Object
 	// OwnerReference is the flag used to by Create operation to
 	// determine if owner reference needs to be set on the created
@@ -57,4 +90,153 @@ type Conf struct {
 	AfterUpdateFunc
 	// AfterDeleteFunc hook is called after deleting the Object
 	AfterDeleteFunc
+	// Timeout limits the duration of every call made to the API
+	// Server. Zero means no limit other than the one of the context
+	// passed.
+	Timeout time.Duration
+	// AfterCreateWithContextFunc hook is called after creating the
+	// Object. If set, it is called instead of AfterCreateFunc.
+	AfterCreateWithContextFunc
+	// AfterUpdateWithContextFunc hook is called after updating the
+	// Object. If set, it is called instead of AfterUpdateFunc.
+	AfterUpdateWithContextFunc
+	// AfterDeleteWithContextFunc hook is called after deleting the
+	// Object. If set, it is called instead of AfterDeleteFunc.
+	AfterDeleteWithContextFunc
+	// BeforeCreateHooks are called in order before creating the
+	// Object. They can mutate the Object or veto the creation.
+	BeforeCreateHooks []BeforeHookFunc
+	// BeforeUpdateHooks are called in order before fetching and
+	// updating the Object, so the changes they make to Object are
+	// seen by MaybeUpdateFunc. They can also veto the update. In
+	// apply mode, they are called before applying the Object.
+	BeforeUpdateHooks []BeforeHookFunc
+	// BeforeDeleteHooks are called in order before deleting the
+	// Object. They can veto the deletion.
+	BeforeDeleteHooks []BeforeHookFunc
+	// AfterCreateHooks are called in order after creating the Object
+	// and the AfterCreate hook.
+	AfterCreateHooks []HookWithContextFunc
+	// AfterUpdateHooks are called in order after updating the Object
+	// and the AfterUpdate hook.
+	AfterUpdateHooks []HookWithContextFunc
+	// AfterDeleteHooks are called in order after deleting the Object
+	// and the AfterDelete hook.
+	AfterDeleteHooks []HookWithContextFunc
+	// UpdateStrategy selects how Update sends the changes made by
+	// MaybeUpdateFunc to API Server. FullUpdate is used by default.
+	UpdateStrategy
+	// ConflictBackoff enables retries when Update fails with
+	// conflict. The object is fetched and compared again before every
+	// retry. Steps is the number of attempts, a single one if not
+	// set. retry.DefaultRetry from client-go is a good default.
+	ConflictBackoff *wait.Backoff
+	// Apply switches CreateOrUpdate to server-side apply. The Object
+	// is sent as an apply patch, so only the fields set in it are
+	// owned and changed, and neither MaybeUpdateFunc nor
+	// ExistingObject is needed. The Object must have its TypeMeta
+	// set. The error is returned as it is if the API Server does not
+	// support apply patches, see EmulateApply. AfterUpdate hooks are
+	// called after the patch as apply does not report if the Object
+	// was created.
+	Apply bool
+	// FieldManager is the name under which the fields are owned in
+	// apply mode. DefaultFieldManager is used if it is empty.
+	FieldManager string
+	// EmulateApply makes apply mode merge patch the Object instead,
+	// creating it if it does not exist, when the API Server, or the
+	// fake client in tests, does not support apply patches. Unlike
+	// apply, the fields removed from the Object are not removed from
+	// the in-cluster object, so it is meant for tests. AfterCreate
+	// hooks are called instead of AfterUpdate hooks if the Object is
+	// created by the emulation.
+	EmulateApply bool
+	// ForceConflicts is used in apply mode to take over the fields
+	// owned by other field managers instead of failing with conflict.
+	ForceConflicts bool
+	// DryRun makes the operation functions skip the changes to the
+	// cluster, see DryRunMode for the modes. After hooks are not
+	// called in dry-run mode since nothing is changed.
+	DryRun DryRunMode
+	// Plan, if set, gets an entry for every object the operation
+	// functions create, update, delete or leave as it is, along with
+	// the fields changed. In apply mode, the diff is computed by
+	// merging the Object into the existing object.
+	Plan *Plan
+	// MaybeUpdateWithDiffFunc is used by Update operation instead of
+	// MaybeUpdateFunc, if set, to update the object and find out the
+	// fields changed.
+	MaybeUpdateWithDiffFunc
+	// DiffHook is called with the fields changed after updating the
+	// Object, see DiffHookFunc.
+	DiffHook DiffHookFunc
+	// Logger, if set, is used to log the operations instead of the
+	// logger from the context or the Reconcile struct, see
+	// logging.Get. Update also logs the fields changed if
+	// logging.ChangeLevel is enabled.
+	Logger logr.Logger
+	// RedactPaths are the JSON pointers of the fields whose values
+	// must not be revealed, like the data of Secret. The values of
+	// these fields, and the fields under them, are replaced with
+	// Redacted in the diffs passed to Plan, DiffHook and Logger.
+	RedactPaths []string
+	// PropagationPolicy is used by Delete operation to decide how the
+	// dependents of the Object are deleted. It can be Foreground,
+	// Background or Orphan. API Server decides the policy if empty.
+	PropagationPolicy metav1.DeletionPropagation
+	// Preconditions are used by Delete operation to delete the Object
+	// only if its UID or resourceVersion match. The deletion fails
+	// with conflict otherwise.
+	Preconditions *metav1.Preconditions
+	// GracePeriodSeconds is used by Delete operation to override the
+	// grace period of the Object. Zero means delete immediately.
+	GracePeriodSeconds *int64
+	// WaitForDeletion makes Delete operation report the deletion as
+	// in progress, using RequeueAfter, until the Object is actually
+	// gone from the cluster. It can take a while for the objects with
+	// finalizers or with Foreground propagation.
+	WaitForDeletion bool
+	// DeletionPollInterval is the RequeueAfter used while waiting for
+	// the deletion. DefaultDeletionPollInterval is used if it is
+	// zero.
+	DeletionPollInterval time.Duration
+	// EventReasons overrides the reasons of the Events recorded when
+	// the Reconcile struct implements interfaces.EventRecorder. In
+	// apply mode, the Updated Event is recorded for every apply, as
+	// apply does not tell if the Object is changed.
+	EventReasons EventReasons
+}
+
+// PruneConf is the struct used by Prune function to find and delete
+// the objects which are not generated by the owner object anymore.
+type PruneConf struct {
+	// Instance is the pointer to the owner object whose children are
+	// pruned. Only the objects with owner reference to the Instance
+	// are deleted.
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of the owner
+	// Object.
+	Reconcile interfaces.Reconcile
+	// GroupVersionKinds are the kinds of the objects which are
+	// pruned.
+	GroupVersionKinds []schema.GroupVersionKind
+	// Namespace limits the objects pruned to the namespace. Empty
+	// namespace means all the namespaces, which is also what is
+	// needed for the cluster-scoped kinds.
+	Namespace string
+	// GenLabelsFunc is used to generate the labels which the objects
+	// pruned must have. It should be the same function used to
+	// generate the labels of the children.
+	meta.GenLabelsFunc
+	// Keep are the objects generated in the current reconcile which
+	// must not be pruned.
+	Keep []interfaces.Object
+	// Timeout limits the duration of every call made to the API
+	// Server.
+	Timeout time.Duration
+	// DryRun makes Prune skip the deletion, see DryRunMode for the
+	// modes.
+	DryRun DryRunMode
+	// Plan, if set, gets an entry for every object pruned.
+	Plan *Plan
 }
@@ -0,0 +1,3 @@
+// Package pdb provides functions for manipulating PodDisruptionBudget
+// object in Kubernetes cluster.
+package pdb
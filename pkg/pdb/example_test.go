@@ -0,0 +1,45 @@
+package pdb_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/pdb"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	result, err := pdb.CreateOrUpdate(pdb.Conf{
+		// Instance is the pointer to owner object under which
+		// PodDisruptionBudget is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the PodDisruptionBudget object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated PodDisruptionBudget.
+		Name: "pdb-test",
+		// GenMaxUnavailableFunc is the function that generates the
+		// maximum number of Pods which can be evicted at a time.
+		GenMaxUnavailableFunc: func(interfaces.Object) (*intstr.IntOrString, error) {
+			maxUnavailable := intstr.FromInt(1)
+			return &maxUnavailable, nil
+		},
+		// GenSelectorFunc is the function that generates the label
+		// selector for the protected Pods.
+		GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
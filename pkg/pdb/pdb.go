@@ -0,0 +1,260 @@
+package pdb
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GeneratePodDisruptionBudget generates PodDisruptionBudget object as
+// per the `Conf` struct passed.
+func GeneratePodDisruptionBudget(c Conf) (pdb *policyv1beta1.PodDisruptionBudget, err error) {
+	var om *metav1.ObjectMeta
+	var minAvailable, maxUnavailable *intstr.IntOrString
+	var selector *metav1.LabelSelector
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenMinAvailableFunc != nil {
+		minAvailable, err = c.GenMinAvailableFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate min available")
+		}
+	}
+
+	if c.GenMaxUnavailableFunc != nil {
+		maxUnavailable, err = c.GenMaxUnavailableFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate max unavailable")
+		}
+	}
+
+	if c.GenSelectorFunc != nil {
+		selector, err = c.GenSelectorFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate selector")
+		}
+	}
+
+	pdb = &policyv1beta1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: "policy/v1beta1",
+		},
+		ObjectMeta: *om,
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MinAvailable:   minAvailable,
+			MaxUnavailable: maxUnavailable,
+			Selector:       selector,
+		},
+	}
+
+	return pdb, nil
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for PodDisruptionBudget
+// object. It compares the specs of the two PodDisruptionBudgets being
+// passed and updates the first one if required. Status is maintained
+// by the disruption controller and is never compared.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	opdb, ok := original.(*policyv1beta1.PodDisruptionBudget)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	npdb, ok := new.(*policyv1beta1.PodDisruptionBudget)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	if equality.Semantic.DeepEqual(opdb.Spec, npdb.Spec) {
+		return false, nil
+	}
+
+	opdb.Spec = npdb.Spec
+	return true, nil
+}
+
+// Create generates the PodDisruptionBudget as per the `Conf` struct
+// passed and creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var pdb *policyv1beta1.PodDisruptionBudget
+	var err error
+	if c.GenPodDisruptionBudgetFunc != nil {
+		pdb, err = c.GenPodDisruptionBudgetFunc(c)
+	} else {
+		pdb, err = GeneratePodDisruptionBudget(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate poddisruptionbudget")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          pdb,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create poddisruptionbudget")
+	}
+
+	return result, nil
+}
+
+// Update generates the PodDisruptionBudget as per the `Conf` struct
+// passed and compares it with the in-cluster version. If required, it
+// updates the in-cluster PodDisruptionBudget with the changes. For
+// comparing the PodDisruptionBudgets, it uses `MaybeUpdate` function
+// by default but can also use `MaybeUpdateFunc` from `Conf` if
+// passed.
+func Update(c Conf) (reconcile.Result, error) {
+	var pdb *policyv1beta1.PodDisruptionBudget
+	var err error
+	if c.GenPodDisruptionBudgetFunc != nil {
+		pdb, err = c.GenPodDisruptionBudgetFunc(c)
+	} else {
+		pdb, err = GeneratePodDisruptionBudget(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate poddisruptionbudget")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          pdb,
+		ExistingObject:  &policyv1beta1.PodDisruptionBudget{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if c.Recreate && immutable(err) {
+		return recreate(c, pdb)
+	}
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update poddisruptionbudget")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update` functions.
+// It creates the PodDisruptionBudget object if it is not already in
+// the cluster and updates the PodDisruptionBudget if one exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var pdb *policyv1beta1.PodDisruptionBudget
+	var err error
+	if c.GenPodDisruptionBudgetFunc != nil {
+		pdb, err = c.GenPodDisruptionBudgetFunc(c)
+	} else {
+		pdb, err = GeneratePodDisruptionBudget(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate poddisruptionbudget")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          pdb,
+		ExistingObject:  &policyv1beta1.PodDisruptionBudget{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if c.Recreate && immutable(err) {
+		return recreate(c, pdb)
+	}
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update poddisruptionbudget")
+	}
+
+	return result, nil
+}
+
+// immutable tells if the update failed because the spec is immutable,
+// either as reported by MaybeUpdateFunc or as rejected by the API
+// Server before Kubernetes 1.15.
+func immutable(err error) bool {
+	return operation.IsImmutableFieldError(err) || kerrors.IsInvalid(errors.Cause(err))
+}
+
+// recreate deletes the in-cluster PodDisruptionBudget and creates the
+// generated PodDisruptionBudget passed.
+func recreate(c Conf, pdb *policyv1beta1.PodDisruptionBudget) (reconcile.Result, error) {
+	result, err := operation.Recreate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          pdb,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to recreate poddisruptionbudget")
+	}
+
+	return result, nil
+}
+
+// Delete generates the ObjectMeta for PodDisruptionBudget as per the
+// `Conf` struct passed and deletes it from the cluster
+func Delete(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for poddisruptionbudget")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &policyv1beta1.PodDisruptionBudget{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete poddisruptionbudget")
+	}
+
+	return result, nil
+}
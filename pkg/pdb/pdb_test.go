@@ -0,0 +1,395 @@
+package pdb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/pdb"
+
+	"github.com/golang/mock/gomock"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func intstrPtr(i intstr.IntOrString) *intstr.IntOrString { return &i }
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+
+	p := &policyv1beta1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-existing-pdb", Namespace: "test"},
+		Spec: policyv1beta1.PodDisruptionBudgetSpec{
+			MinAvailable: intstrPtr(intstr.FromInt(1)),
+			Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+		},
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{p}...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+// immutableClient rejects the updates to PodDisruptionBudget as the
+// API Server does before Kubernetes 1.15.
+type immutableClient struct {
+	client.Client
+}
+
+func (c *immutableClient) Update(_ context.Context, obj runtime.Object, _ ...client.UpdateOption) error {
+	p := obj.(*policyv1beta1.PodDisruptionBudget)
+	return kerrors.NewInvalid(schema.GroupKind{Group: "policy", Kind: "PodDisruptionBudget"}, p.Name, field.ErrorList{
+		field.Forbidden(field.NewPath("spec"), "updates to poddisruptionbudget spec are forbidden."),
+	})
+}
+
+// immutableMockSetup creates the mocks with immutableClient.
+func immutableMockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i, m := mockSetup(ctrl)
+	c := &immutableClient{Client: m.GetClient()}
+
+	r = mocks.NewMockReconcile(ctrl)
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(m.GetScheme()).AnyTimes()
+
+	return i, r
+}
+
+func TestGeneratePodDisruptionBudget(t *testing.T) {
+	t.Run("generate empty poddisruptionbudget", func(t *testing.T) {
+		expected := &policyv1beta1.PodDisruptionBudget{TypeMeta: metav1.TypeMeta{
+			Kind:       "PodDisruptionBudget",
+			APIVersion: "policy/v1beta1",
+		}}
+
+		result, err := pdb.GeneratePodDisruptionBudget(pdb.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := pdb.GeneratePodDisruptionBudget(pdb.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate min available", func(t *testing.T) {
+		result, err := pdb.GeneratePodDisruptionBudget(pdb.Conf{
+			GenMinAvailableFunc: func(interfaces.Object) (*intstr.IntOrString, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate max unavailable", func(t *testing.T) {
+		result, err := pdb.GeneratePodDisruptionBudget(pdb.Conf{
+			GenMaxUnavailableFunc: func(interfaces.Object) (*intstr.IntOrString, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate selector", func(t *testing.T) {
+		result, err := pdb.GeneratePodDisruptionBudget(pdb.Conf{
+			GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate poddisruptionbudget", func(t *testing.T) {
+		expected := &policyv1beta1.PodDisruptionBudget{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "PodDisruptionBudget",
+				APIVersion: "policy/v1beta1",
+			},
+			Spec: policyv1beta1.PodDisruptionBudgetSpec{
+				MaxUnavailable: intstrPtr(intstr.FromString("25%")),
+				Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			},
+		}
+
+		result, err := pdb.GeneratePodDisruptionBudget(pdb.Conf{
+			GenMaxUnavailableFunc: func(interfaces.Object) (*intstr.IntOrString, error) {
+				return intstrPtr(intstr.FromString("25%")), nil
+			},
+			GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+				return &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := pdb.MaybeUpdate(&mocks.MockObject{}, &policyv1beta1.PodDisruptionBudget{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := pdb.MaybeUpdate(&policyv1beta1.PodDisruptionBudget{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := pdb.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare poddisruptionbudgets", func(t *testing.T) {
+		t.Run("empty poddisruptionbudgets", func(t *testing.T) {
+			result, err := pdb.MaybeUpdate(&policyv1beta1.PodDisruptionBudget{}, &policyv1beta1.PodDisruptionBudget{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("status is ignored", func(t *testing.T) {
+			existingPodDisruptionBudget := &policyv1beta1.PodDisruptionBudget{
+				Status: policyv1beta1.PodDisruptionBudgetStatus{CurrentHealthy: 3, DesiredHealthy: 2, ExpectedPods: 3},
+			}
+
+			result, err := pdb.MaybeUpdate(existingPodDisruptionBudget, &policyv1beta1.PodDisruptionBudget{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different spec", func(t *testing.T) {
+			existingPodDisruptionBudget := &policyv1beta1.PodDisruptionBudget{
+				Spec: policyv1beta1.PodDisruptionBudgetSpec{MinAvailable: intstrPtr(intstr.FromInt(1))},
+			}
+			newPodDisruptionBudget := &policyv1beta1.PodDisruptionBudget{
+				Spec: policyv1beta1.PodDisruptionBudgetSpec{MinAvailable: intstrPtr(intstr.FromInt(2))},
+			}
+
+			result, err := pdb.MaybeUpdate(existingPodDisruptionBudget, newPodDisruptionBudget)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, intstr.FromInt(2), *existingPodDisruptionBudget.Spec.MinAvailable)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := pdb.Create(pdb.Conf{GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := pdb.Create(pdb.Conf{GenPodDisruptionBudgetFunc: func(pdb.Conf) (*policyv1beta1.PodDisruptionBudget, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pdb.Create(pdb.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create poddisruptionbudget", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pdb.Create(pdb.Conf{
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := pdb.Update(pdb.Conf{GenPodDisruptionBudgetFunc: func(pdb.Conf) (*policyv1beta1.PodDisruptionBudget, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pdb.Update(pdb.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("update poddisruptionbudget with changed spec", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pdb.Update(pdb.Conf{
+			Name:      "test-existing-pdb",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+			GenMinAvailableFunc: func(interfaces.Object) (*intstr.IntOrString, error) {
+				return intstrPtr(intstr.FromInt(2)), nil
+			},
+		})
+		assert.NoError(t, err)
+
+		p := &policyv1beta1.PodDisruptionBudget{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-pdb", Namespace: "test"}, p)
+		assert.NoError(t, err)
+		assert.Equal(t, intstr.FromInt(2), *p.Spec.MinAvailable)
+	})
+	t.Run("update rejected as invalid", func(t *testing.T) {
+		i, r := immutableMockSetup(controller)
+		_, err := pdb.Update(pdb.Conf{
+			Name:      "test-existing-pdb",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+			GenMinAvailableFunc: func(interfaces.Object) (*intstr.IntOrString, error) {
+				return intstrPtr(intstr.FromInt(2)), nil
+			},
+		})
+		assert.True(t, kerrors.IsInvalid(pkgerrors.Cause(err)))
+	})
+	t.Run("recreate poddisruptionbudget rejected as invalid", func(t *testing.T) {
+		i, r := immutableMockSetup(controller)
+		_, err := pdb.Update(pdb.Conf{
+			Name:      "test-existing-pdb",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+			Recreate:  true,
+			GenMinAvailableFunc: func(interfaces.Object) (*intstr.IntOrString, error) {
+				return intstrPtr(intstr.FromInt(2)), nil
+			},
+		})
+		assert.NoError(t, err)
+
+		p := &policyv1beta1.PodDisruptionBudget{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-pdb", Namespace: "test"}, p)
+		assert.NoError(t, err)
+		assert.Equal(t, intstr.FromInt(2), *p.Spec.MinAvailable)
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := pdb.CreateOrUpdate(pdb.Conf{GenPodDisruptionBudgetFunc: func(pdb.Conf) (*policyv1beta1.PodDisruptionBudget, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pdb.CreateOrUpdate(pdb.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update poddisruptionbudget", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pdb.CreateOrUpdate(pdb.Conf{
+			Name:      "test-existing-pdb",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+			Recreate:  true,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("create or update poddisruptionbudget with changed spec", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pdb.CreateOrUpdate(pdb.Conf{
+			Name:      "test-existing-pdb",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("recreate poddisruptionbudget rejected as invalid", func(t *testing.T) {
+		i, r := immutableMockSetup(controller)
+		_, err := pdb.CreateOrUpdate(pdb.Conf{
+			Name:      "test-existing-pdb",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+			Recreate:  true,
+		})
+		assert.NoError(t, err)
+
+		p := &policyv1beta1.PodDisruptionBudget{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-pdb", Namespace: "test"}, p)
+		assert.NoError(t, err)
+		assert.Nil(t, p.Spec.MinAvailable)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := pdb.Delete(pdb.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to delete", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pdb.Delete(pdb.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("delete poddisruptionbudget", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pdb.Delete(pdb.Conf{
+			Name:      "test-existing-pdb",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
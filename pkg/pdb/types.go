@@ -0,0 +1,89 @@
+package pdb
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// GenPodDisruptionBudgetFunc defines a function which generates
+// PodDisruptionBudget
+type GenPodDisruptionBudgetFunc func(Conf) (*policyv1beta1.PodDisruptionBudget, error)
+
+// GenMinAvailableFunc defines a function which generates the number
+// or percentage of Pods which must be available after an eviction
+type GenMinAvailableFunc func(interfaces.Object) (*intstr.IntOrString, error)
+
+// GenMaxUnavailableFunc defines a function which generates the
+// number or percentage of Pods which can be unavailable after an
+// eviction
+type GenMaxUnavailableFunc func(interfaces.Object) (*intstr.IntOrString, error)
+
+// GenSelectorFunc defines a function which generates label selector
+// for the Pods protected by the PodDisruptionBudget
+type GenSelectorFunc func(interfaces.Object) (*metav1.LabelSelector, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on PodDisruptionBudget objects.
+type Conf struct {
+	// Instance is the Owner object which manages the
+	// PodDisruptionBudget
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the PodDisruptionBudget
+	Name string
+	// Namespace of the PodDisruptionBudget
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on PodDisruptionBudget before creating it in cluster
+	OwnerReference bool
+	// Recreate is used to determine if the PodDisruptionBudget should
+	// be deleted and created again when the update is rejected as
+	// invalid by the API Server, as the spec is immutable before
+	// Kubernetes 1.15, instead of returning the error.
+	Recreate bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for PodDisruptionBudget update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the
+	// PodDisruptionBudget
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the
+	// PodDisruptionBudget
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the
+	// PodDisruptionBudget
+	operation.AfterDeleteFunc
+	// GenPodDisruptionBudgetFunc defines a function to generate the
+	// PodDisruptionBudget object. The package comes with default
+	// generator function which is used by operation functions. By
+	// specifying this field, user can override the default function
+	// with a custom one.
+	GenPodDisruptionBudgetFunc
+	// GenMinAvailableFunc defines a function to generate minimum
+	// available Pods for the PodDisruptionBudget. It is mutually
+	// exclusive with GenMaxUnavailableFunc.
+	GenMinAvailableFunc
+	// GenMaxUnavailableFunc defines a function to generate maximum
+	// unavailable Pods for the PodDisruptionBudget. It is mutually
+	// exclusive with GenMinAvailableFunc.
+	GenMaxUnavailableFunc
+	// GenSelectorFunc defines a function to generate label selector
+	// for the PodDisruptionBudget
+	GenSelectorFunc
+}
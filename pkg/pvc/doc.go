@@ -0,0 +1,3 @@
+// Package pvc provides functions for manipulating
+// PersistentVolumeClaim object in Kubernetes cluster.
+package pvc
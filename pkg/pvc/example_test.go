@@ -0,0 +1,52 @@
+package pvc_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/pvc"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	result, err := pvc.CreateOrUpdate(pvc.Conf{
+		// Instance is the pointer to owner object under which
+		// PersistentVolumeClaim is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the PersistentVolumeClaim object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated PersistentVolumeClaim.
+		Name: "pvc-test",
+		// GenAccessModesFunc is the function that generates the access
+		// modes of the PersistentVolumeClaim. This field is immutable.
+		GenAccessModesFunc: func(interfaces.Object) ([]corev1.PersistentVolumeAccessMode, error) {
+			return []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, nil
+		},
+		// GenResourcesFunc is the function that generates the
+		// requested storage. The request can only be increased.
+		GenResourcesFunc: func(interfaces.Object) (corev1.ResourceRequirements, error) {
+			return corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+			}, nil
+		},
+		// GenAnnotationsFunc can be used to set the retain annotation
+		// so that Delete leaves the PersistentVolumeClaim in place.
+		// Owner reference is not set on the retained claims.
+		GenAnnotationsFunc: func(interfaces.Object) (map[string]string, error) {
+			return map[string]string{pvc.RetainAnnotation: "true"}, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
@@ -0,0 +1,300 @@
+package pvc
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GeneratePersistentVolumeClaim generates PersistentVolumeClaim
+// object as per the `Conf` struct passed.
+func GeneratePersistentVolumeClaim(c Conf) (pvc *corev1.PersistentVolumeClaim, err error) {
+	var om *metav1.ObjectMeta
+	var accessModes []corev1.PersistentVolumeAccessMode
+	var resources corev1.ResourceRequirements
+	var selector *metav1.LabelSelector
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenAccessModesFunc != nil {
+		accessModes, err = c.GenAccessModesFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate access modes")
+		}
+	}
+
+	if c.GenResourcesFunc != nil {
+		resources, err = c.GenResourcesFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate resources")
+		}
+	}
+
+	if c.GenSelectorFunc != nil {
+		selector, err = c.GenSelectorFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate selector")
+		}
+	}
+
+	pvc = &corev1.PersistentVolumeClaim{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		},
+		ObjectMeta: *om,
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      accessModes,
+			Resources:        resources,
+			Selector:         selector,
+			StorageClassName: c.StorageClassName,
+		},
+	}
+
+	return pvc, nil
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for PersistentVolumeClaim
+// object. It compares the two PersistentVolumeClaims being passed and
+// update the first one if required. Increasing the storage request is
+// the only in-place change Kubernetes permits on a bound claim (and
+// even that requires the StorageClass to allow volume expansion), so
+// that is the only field this function updates. Shrinking the claim
+// returns error and so does changing the access modes or the storage
+// class, which are immutable. Access modes and storage class are only
+// compared if set in the generated claim since API Server and the
+// default StorageClass fill them otherwise.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	opvc, ok := original.(*corev1.PersistentVolumeClaim)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	npvc, ok := new.(*corev1.PersistentVolumeClaim)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	if len(npvc.Spec.AccessModes) != 0 && !reflect.DeepEqual(opvc.Spec.AccessModes, npvc.Spec.AccessModes) {
+		return false, operation.NewImmutableFieldError("PersistentVolumeClaim", "spec.accessModes")
+	}
+
+	if npvc.Spec.StorageClassName != nil &&
+		(opvc.Spec.StorageClassName == nil || *opvc.Spec.StorageClassName != *npvc.Spec.StorageClassName) {
+		return false, operation.NewImmutableFieldError("PersistentVolumeClaim", "spec.storageClassName")
+	}
+
+	newStorage, ok := npvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	if !ok {
+		return false, nil
+	}
+
+	oldStorage := opvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	switch newStorage.Cmp(oldStorage) {
+	case -1:
+		return false, errors.Errorf("storage request of persistentvolumeclaim cannot be decreased from %s to %s", oldStorage.String(), newStorage.String())
+	case 0:
+		return false, nil
+	}
+
+	if opvc.Spec.Resources.Requests == nil {
+		opvc.Spec.Resources.Requests = corev1.ResourceList{}
+	}
+	opvc.Spec.Resources.Requests[corev1.ResourceStorage] = newStorage
+
+	return true, nil
+}
+
+// Create generates the PersistentVolumeClaim as per the `Conf` struct
+// passed and creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var pvc *corev1.PersistentVolumeClaim
+	var err error
+	if c.GenPersistentVolumeClaimFunc != nil {
+		pvc, err = c.GenPersistentVolumeClaimFunc(c)
+	} else {
+		pvc, err = GeneratePersistentVolumeClaim(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate persistentvolumeclaim")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          pvc,
+		OwnerReference:  ownerReference(c, pvc),
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create persistentvolumeclaim")
+	}
+
+	return result, nil
+}
+
+// Update generates the PersistentVolumeClaim as per the `Conf` struct
+// passed and compares it with the in-cluster version. If required, it
+// updates the in-cluster PersistentVolumeClaim with the changes. For
+// comparing the PersistentVolumeClaims, it uses `MaybeUpdate` function
+// by default but can also use `MaybeUpdateFunc` from `Conf` if passed.
+func Update(c Conf) (reconcile.Result, error) {
+	var pvc *corev1.PersistentVolumeClaim
+	var err error
+	if c.GenPersistentVolumeClaimFunc != nil {
+		pvc, err = c.GenPersistentVolumeClaimFunc(c)
+	} else {
+		pvc, err = GeneratePersistentVolumeClaim(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate persistentvolumeclaim")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          pvc,
+		ExistingObject:  &corev1.PersistentVolumeClaim{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update persistentvolumeclaim")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update`
+// functions. It creates the PersistentVolumeClaim object if it is not
+// already in the cluster and updates the PersistentVolumeClaim if one
+// exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var pvc *corev1.PersistentVolumeClaim
+	var err error
+	if c.GenPersistentVolumeClaimFunc != nil {
+		pvc, err = c.GenPersistentVolumeClaimFunc(c)
+	} else {
+		pvc, err = GeneratePersistentVolumeClaim(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate persistentvolumeclaim")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          pvc,
+		ExistingObject:  &corev1.PersistentVolumeClaim{},
+		OwnerReference:  ownerReference(c, pvc),
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update persistentvolumeclaim")
+	}
+
+	return result, nil
+}
+
+// ownerReference tells if owner reference can be set on the
+// generated PersistentVolumeClaim. It is not set on the retained
+// claims as garbage collector would delete them along with the owner.
+func ownerReference(c Conf, pvc *corev1.PersistentVolumeClaim) bool {
+	return c.OwnerReference && pvc.Annotations[RetainAnnotation] != "true"
+}
+
+// Delete generates the ObjectMeta for PersistentVolumeClaim as per the
+// `Conf` struct passed and deletes it from the cluster. If the
+// PersistentVolumeClaim in the cluster has RetainAnnotation set to
+// "true", the deletion is skipped so that the data is not lost by
+// accident. The annotation can either be generated or set on the
+// claim manually. Set WaitForDeletion in `Conf` to wait until the
+// claim is gone. The claim is deleted only if it is not changed after
+// checking the annotation, otherwise the deletion fails with conflict.
+func Delete(c Conf) (reconcile.Result, error) {
+	return DeleteWithContext(context.Background(), c)
+}
+
+// DeleteWithContext is same as Delete but uses the context passed for
+// the calls to API Server and the hooks.
+func DeleteWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for persistentvolumeclaim")
+	}
+
+	existing := &corev1.PersistentVolumeClaim{}
+	err = c.Reconcile.GetClient().Get(ctx, types.NamespacedName{Name: om.Name, Namespace: om.Namespace}, existing)
+	if err != nil && !kerrors.IsNotFound(err) {
+		return reconcile.Result{}, errors.Wrap(err, "failed to get the existing persistentvolumeclaim from cluster")
+	}
+
+	// The annotation can be set between the check and the deletion, so
+	// delete the claim only if it is still the one checked.
+	var preconditions *metav1.Preconditions
+	if err == nil {
+		if existing.Annotations[RetainAnnotation] == "true" {
+			return reconcile.Result{}, nil
+		}
+		preconditions = &metav1.Preconditions{UID: &existing.UID, ResourceVersion: &existing.ResourceVersion}
+	}
+
+	result, err := operation.DeleteWithContext(ctx, operation.Conf{
+		Instance:             c.Instance,
+		Reconcile:            c.Reconcile,
+		Object:               &corev1.PersistentVolumeClaim{ObjectMeta: *om},
+		Preconditions:        preconditions,
+		AfterDeleteFunc:      c.AfterDeleteFunc,
+		WaitForDeletion:      c.WaitForDeletion,
+		DeletionPollInterval: c.DeletionPollInterval,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete persistentvolumeclaim")
+	}
+
+	return result, nil
+}
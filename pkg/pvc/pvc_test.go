@@ -0,0 +1,480 @@
+package pvc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+	"github.com/ankitrgadiya/operatorlib/pkg/pvc"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func stringPtr(s string) *string { return &s }
+
+func storage(size string) corev1.ResourceRequirements {
+	return corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+	}
+}
+
+func testStorage(size string) func(interfaces.Object) (corev1.ResourceRequirements, error) {
+	return func(interfaces.Object) (corev1.ResourceRequirements, error) {
+		return storage(size), nil
+	}
+}
+
+func testClaim(name string, annotations map[string]string) *corev1.PersistentVolumeClaim {
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test", Annotations: annotations},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: stringPtr("standard"),
+			Resources:        storage("1Gi"),
+		},
+	}
+}
+
+// deleteOptionsClient records the options of the delete requests.
+type deleteOptionsClient struct {
+	client.Client
+	opts client.DeleteOptions
+}
+
+func (c *deleteOptionsClient) Delete(ctx context.Context, obj runtime.Object, opts ...client.DeleteOption) error {
+	c.opts = client.DeleteOptions{}
+	c.opts.ApplyOptions(opts)
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{
+		testClaim("test-existing-pvc", nil),
+		testClaim("test-retained-pvc", map[string]string{pvc.RetainAnnotation: "true"}),
+	}...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+func TestGeneratePersistentVolumeClaim(t *testing.T) {
+	t.Run("generate empty persistentvolumeclaim", func(t *testing.T) {
+		expected := &corev1.PersistentVolumeClaim{TypeMeta: metav1.TypeMeta{
+			Kind:       "PersistentVolumeClaim",
+			APIVersion: "v1",
+		}}
+
+		result, err := pvc.GeneratePersistentVolumeClaim(pvc.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := pvc.GeneratePersistentVolumeClaim(pvc.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate access modes", func(t *testing.T) {
+		result, err := pvc.GeneratePersistentVolumeClaim(pvc.Conf{
+			GenAccessModesFunc: func(interfaces.Object) ([]corev1.PersistentVolumeAccessMode, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate resources", func(t *testing.T) {
+		result, err := pvc.GeneratePersistentVolumeClaim(pvc.Conf{
+			GenResourcesFunc: func(interfaces.Object) (corev1.ResourceRequirements, error) {
+				return corev1.ResourceRequirements{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate selector", func(t *testing.T) {
+		result, err := pvc.GeneratePersistentVolumeClaim(pvc.Conf{
+			GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate persistentvolumeclaim", func(t *testing.T) {
+		expected := &corev1.PersistentVolumeClaim{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "PersistentVolumeClaim",
+				APIVersion: "v1",
+			},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources:        storage("1Gi"),
+				Selector:         &metav1.LabelSelector{MatchLabels: map[string]string{"key": "value"}},
+				StorageClassName: stringPtr("standard"),
+			},
+		}
+
+		result, err := pvc.GeneratePersistentVolumeClaim(pvc.Conf{
+			GenAccessModesFunc: func(interfaces.Object) ([]corev1.PersistentVolumeAccessMode, error) {
+				return []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, nil
+			},
+			GenResourcesFunc: testStorage("1Gi"),
+			GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+				return &metav1.LabelSelector{MatchLabels: map[string]string{"key": "value"}}, nil
+			},
+			StorageClassName: stringPtr("standard"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := pvc.MaybeUpdate(&mocks.MockObject{}, &corev1.PersistentVolumeClaim{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := pvc.MaybeUpdate(&corev1.PersistentVolumeClaim{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := pvc.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare persistentvolumeclaims", func(t *testing.T) {
+		t.Run("empty persistentvolumeclaims", func(t *testing.T) {
+			result, err := pvc.MaybeUpdate(&corev1.PersistentVolumeClaim{}, &corev1.PersistentVolumeClaim{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("up-to-date persistentvolumeclaims", func(t *testing.T) {
+			result, err := pvc.MaybeUpdate(testClaim("test", nil), testClaim("test", nil))
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different access modes", func(t *testing.T) {
+			newClaim := testClaim("test", nil)
+			newClaim.Spec.AccessModes = []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+
+			result, err := pvc.MaybeUpdate(testClaim("test", nil), newClaim)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+		t.Run("different storage class", func(t *testing.T) {
+			newClaim := testClaim("test", nil)
+			newClaim.Spec.StorageClassName = stringPtr("fast")
+
+			result, err := pvc.MaybeUpdate(testClaim("test", nil), newClaim)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+		t.Run("defaulted storage class", func(t *testing.T) {
+			newClaim := testClaim("test", nil)
+			newClaim.Spec.StorageClassName = nil
+
+			result, err := pvc.MaybeUpdate(testClaim("test", nil), newClaim)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("decreased storage request", func(t *testing.T) {
+			newClaim := testClaim("test", nil)
+			newClaim.Spec.Resources = storage("512Mi")
+
+			result, err := pvc.MaybeUpdate(testClaim("test", nil), newClaim)
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("equal storage request in different units", func(t *testing.T) {
+			newClaim := testClaim("test", nil)
+			newClaim.Spec.Resources = storage("1024Mi")
+
+			result, err := pvc.MaybeUpdate(testClaim("test", nil), newClaim)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("increased storage request", func(t *testing.T) {
+			existingClaim := testClaim("test", nil)
+			newClaim := testClaim("test", nil)
+			newClaim.Spec.Resources = storage("2Gi")
+
+			result, err := pvc.MaybeUpdate(existingClaim, newClaim)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingClaim, newClaim)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := pvc.Create(pvc.Conf{GenResourcesFunc: func(interfaces.Object) (corev1.ResourceRequirements, error) {
+			return corev1.ResourceRequirements{}, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := pvc.Create(pvc.Conf{GenPersistentVolumeClaimFunc: func(pvc.Conf) (*corev1.PersistentVolumeClaim, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pvc.Create(pvc.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create persistentvolumeclaim", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pvc.Create(pvc.Conf{
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("create persistentvolumeclaim with owner reference", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pvc.Create(pvc.Conf{
+			Name:           "test-pvc",
+			Namespace:      "test",
+			Instance:       i,
+			Reconcile:      r,
+			OwnerReference: true,
+		})
+		assert.NoError(t, err)
+
+		result := &corev1.PersistentVolumeClaim{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-pvc", Namespace: "test"}, result)
+		assert.NoError(t, err)
+		assert.Len(t, result.OwnerReferences, 1)
+	})
+	t.Run("owner reference is not set on retained persistentvolumeclaim", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pvc.Create(pvc.Conf{
+			Name:           "test-pvc",
+			Namespace:      "test",
+			Instance:       i,
+			Reconcile:      r,
+			OwnerReference: true,
+			GenAnnotationsFunc: func(interfaces.Object) (map[string]string, error) {
+				return map[string]string{pvc.RetainAnnotation: "true"}, nil
+			},
+		})
+		assert.NoError(t, err)
+
+		result := &corev1.PersistentVolumeClaim{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-pvc", Namespace: "test"}, result)
+		assert.NoError(t, err)
+		assert.Empty(t, result.OwnerReferences)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := pvc.Update(pvc.Conf{GenResourcesFunc: func(interfaces.Object) (corev1.ResourceRequirements, error) {
+			return corev1.ResourceRequirements{}, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := pvc.Update(pvc.Conf{GenPersistentVolumeClaimFunc: func(pvc.Conf) (*corev1.PersistentVolumeClaim, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("shrink persistentvolumeclaim", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pvc.Update(pvc.Conf{
+			Name:             "test-existing-pvc",
+			Namespace:        "test",
+			Instance:         i,
+			Reconcile:        r,
+			GenResourcesFunc: testStorage("512Mi"),
+		})
+		assert.Error(t, err)
+	})
+	t.Run("resize persistentvolumeclaim", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pvc.Update(pvc.Conf{
+			Name:             "test-existing-pvc",
+			Namespace:        "test",
+			Instance:         i,
+			Reconcile:        r,
+			GenResourcesFunc: testStorage("2Gi"),
+		})
+		assert.NoError(t, err)
+
+		result := &corev1.PersistentVolumeClaim{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-pvc", Namespace: "test"}, result)
+		assert.NoError(t, err)
+		size := result.Spec.Resources.Requests[corev1.ResourceStorage]
+		assert.Equal(t, "2Gi", size.String())
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := pvc.CreateOrUpdate(pvc.Conf{GenResourcesFunc: func(interfaces.Object) (corev1.ResourceRequirements, error) {
+			return corev1.ResourceRequirements{}, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := pvc.CreateOrUpdate(pvc.Conf{GenPersistentVolumeClaimFunc: func(pvc.Conf) (*corev1.PersistentVolumeClaim, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pvc.CreateOrUpdate(pvc.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update persistentvolumeclaim", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pvc.CreateOrUpdate(pvc.Conf{
+			Name:             "test-existing-pvc",
+			Namespace:        "test",
+			Instance:         i,
+			Reconcile:        r,
+			GenResourcesFunc: testStorage("2Gi"),
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := pvc.Delete(pvc.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to delete", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pvc.Delete(pvc.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("delete persistentvolumeclaim", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pvc.Delete(pvc.Conf{
+			Name:      "test-existing-pvc",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-pvc", Namespace: "test"}, &corev1.PersistentVolumeClaim{})
+		assert.Error(t, err)
+	})
+	t.Run("delete persistentvolumeclaim and wait", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		result, err := pvc.Delete(pvc.Conf{
+			Name:                 "test-existing-pvc",
+			Namespace:            "test",
+			Instance:             i,
+			Reconcile:            r,
+			WaitForDeletion:      true,
+			DeletionPollInterval: time.Second,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, reconcile.Result{}, result)
+	})
+	t.Run("delete persistentvolumeclaim with preconditions", func(t *testing.T) {
+		i, fr := mockSetup(controller)
+		existing := &corev1.PersistentVolumeClaim{}
+		err := fr.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-pvc", Namespace: "test"}, existing)
+		assert.NoError(t, err)
+		c := &deleteOptionsClient{Client: fr.GetClient()}
+		r := mocks.NewMockReconcile(controller)
+		r.EXPECT().GetClient().Return(c).AnyTimes()
+		r.EXPECT().GetScheme().Return(fr.GetScheme()).AnyTimes()
+
+		_, err = pvc.DeleteWithContext(context.TODO(), pvc.Conf{
+			Name:      "test-existing-pvc",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+		if assert.NotNil(t, c.opts.Preconditions) {
+			assert.Equal(t, existing.UID, *c.opts.Preconditions.UID)
+			assert.Equal(t, existing.ResourceVersion, *c.opts.Preconditions.ResourceVersion)
+		}
+	})
+	t.Run("retained persistentvolumeclaim", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := pvc.Delete(pvc.Conf{
+			Name:      "test-retained-pvc",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-retained-pvc", Namespace: "test"}, &corev1.PersistentVolumeClaim{})
+		assert.NoError(t, err)
+	})
+}
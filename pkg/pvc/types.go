@@ -0,0 +1,107 @@
+package pvc
+
+import (
+	"time"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RetainAnnotation is the annotation which marks the
+// PersistentVolumeClaim as retained. Delete function skips the
+// deletion of the PersistentVolumeClaim if it has this annotation set
+// to "true" and owner reference is not set on the generated
+// PersistentVolumeClaim with it.
+const RetainAnnotation = "operatorlib/retain"
+
+// GenPersistentVolumeClaimFunc defines a function which generates
+// PersistentVolumeClaim
+type GenPersistentVolumeClaimFunc func(Conf) (*corev1.PersistentVolumeClaim, error)
+
+// GenAccessModesFunc defines a function which generates access modes
+// for the PersistentVolumeClaim
+type GenAccessModesFunc func(interfaces.Object) ([]corev1.PersistentVolumeAccessMode, error)
+
+// GenResourcesFunc defines a function which generates resources for
+// the PersistentVolumeClaim
+type GenResourcesFunc func(interfaces.Object) (corev1.ResourceRequirements, error)
+
+// GenSelectorFunc defines a function which generates label selector
+// for selecting PersistentVolumes for the PersistentVolumeClaim
+type GenSelectorFunc func(interfaces.Object) (*metav1.LabelSelector, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on PersistentVolumeClaim objects.
+type Conf struct {
+	// Instance is the Owner object which manages the
+	// PersistentVolumeClaim
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the PersistentVolumeClaim
+	Name string
+	// Namespace of the PersistentVolumeClaim
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on PersistentVolumeClaim before creating it in
+	// cluster. It is not set if the generated PersistentVolumeClaim
+	// has RetainAnnotation set to "true", so that garbage collector
+	// does not delete it along with the owner. Setting the annotation
+	// manually does not remove the owner reference already set.
+	OwnerReference bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for PersistentVolumeClaim update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the
+	// PersistentVolumeClaim
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the
+	// PersistentVolumeClaim
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the
+	// PersistentVolumeClaim
+	operation.AfterDeleteFunc
+	// GenPersistentVolumeClaimFunc defines a function to generate
+	// the PersistentVolumeClaim object. The package comes with
+	// default generator function which is used by operation
+	// functions. By specifying this field, user can override the
+	// default function with a custom one.
+	GenPersistentVolumeClaimFunc
+	// GenAccessModesFunc defines a function to generate access modes
+	// for the PersistentVolumeClaim
+	GenAccessModesFunc
+	// GenResourcesFunc defines a function to generate resources,
+	// most importantly storage request, for the
+	// PersistentVolumeClaim
+	GenResourcesFunc
+	// GenSelectorFunc defines a function to generate label selector
+	// for the PersistentVolumeClaim
+	GenSelectorFunc
+	// StorageClassName is the name of StorageClass required by the
+	// PersistentVolumeClaim. If nil, the default StorageClass is
+	// used.
+	StorageClassName *string
+	// WaitForDeletion makes Delete report the deletion as in
+	// progress, using RequeueAfter, until the PersistentVolumeClaim
+	// is gone. The claims in use are not deleted until their Pods
+	// are, so this helps in creating new claims with the same names
+	// only after the old ones are gone.
+	WaitForDeletion bool
+	// DeletionPollInterval is the RequeueAfter used while waiting for
+	// the deletion
+	DeletionPollInterval time.Duration
+}
@@ -0,0 +1,227 @@
+package rbac
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateClusterRole generates ClusterRole object as per the `Conf`
+// struct passed. Namespace from `Conf` is ignored since ClusterRole
+// is cluster-scoped.
+func GenerateClusterRole(c Conf) (cr *rbacv1.ClusterRole, err error) {
+	var om *metav1.ObjectMeta
+	var rules []rbacv1.PolicyRule
+	var aggregationRule *rbacv1.AggregationRule
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenRulesFunc != nil {
+		rules, err = c.GenRulesFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate rules")
+		}
+	}
+
+	if c.GenAggregationRuleFunc != nil {
+		aggregationRule, err = c.GenAggregationRuleFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate aggregation rule")
+		}
+	}
+
+	cr = &rbacv1.ClusterRole{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRole",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta:      *om,
+		Rules:           rules,
+		AggregationRule: aggregationRule,
+	}
+
+	return cr, nil
+}
+
+// MaybeUpdateClusterRole implements MaybeUpdateFunc for ClusterRole
+// object. It compares the two ClusterRoles being passed and update
+// the first one if required. Rules of an aggregated ClusterRole are
+// filled in by the controller manager, so those are only compared if
+// the new ClusterRole does not have the aggregation rule.
+func MaybeUpdateClusterRole(original interfaces.Object, new interfaces.Object) (bool, error) {
+	ocr, ok := original.(*rbacv1.ClusterRole)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	ncr, ok := new.(*rbacv1.ClusterRole)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	update := false
+
+	if !equality.Semantic.DeepEqual(ocr.AggregationRule, ncr.AggregationRule) {
+		ocr.AggregationRule = ncr.AggregationRule
+		update = true
+	}
+
+	if ncr.AggregationRule == nil && !equality.Semantic.DeepEqual(ocr.Rules, ncr.Rules) {
+		ocr.Rules = ncr.Rules
+		update = true
+	}
+
+	return update, nil
+}
+
+// CreateClusterRole generates the ClusterRole as per the `Conf` struct
+// passed and creates it in the cluster. Owner reference is only set
+// if the owner is cluster-scoped.
+func CreateClusterRole(c Conf) (reconcile.Result, error) {
+	var cr *rbacv1.ClusterRole
+	var err error
+	if c.GenClusterRoleFunc != nil {
+		cr, err = c.GenClusterRoleFunc(c)
+	} else {
+		cr, err = GenerateClusterRole(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate clusterrole")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          cr,
+		OwnerReference:  clusterOwnerReference(c, "ClusterRole"),
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create clusterrole")
+	}
+
+	return result, nil
+}
+
+// UpdateClusterRole generates the ClusterRole as per the `Conf`
+// struct passed and compares it with the in-cluster version. If
+// required, it updates the in-cluster ClusterRole with the changes.
+// For comparing the ClusterRoles, it uses `MaybeUpdateClusterRole`
+// function by default but can also use `MaybeUpdateFunc` from `Conf`
+// if passed.
+func UpdateClusterRole(c Conf) (reconcile.Result, error) {
+	var cr *rbacv1.ClusterRole
+	var err error
+	if c.GenClusterRoleFunc != nil {
+		cr, err = c.GenClusterRoleFunc(c)
+	} else {
+		cr, err = GenerateClusterRole(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate clusterrole")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdateClusterRole
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          cr,
+		ExistingObject:  &rbacv1.ClusterRole{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update clusterrole")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdateClusterRole is a combination of `CreateClusterRole`
+// and `UpdateClusterRole` functions. It creates the ClusterRole
+// object if it is not already in the cluster and updates the
+// ClusterRole if one exists.
+func CreateOrUpdateClusterRole(c Conf) (reconcile.Result, error) {
+	var cr *rbacv1.ClusterRole
+	var err error
+	if c.GenClusterRoleFunc != nil {
+		cr, err = c.GenClusterRoleFunc(c)
+	} else {
+		cr, err = GenerateClusterRole(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate clusterrole")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdateClusterRole
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          cr,
+		ExistingObject:  &rbacv1.ClusterRole{},
+		OwnerReference:  clusterOwnerReference(c, "ClusterRole"),
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update clusterrole")
+	}
+
+	return result, nil
+}
+
+// DeleteClusterRole generates the ObjectMeta for ClusterRole as per
+// the `Conf` struct passed and deletes it from the cluster
+func DeleteClusterRole(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for clusterrole")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &rbacv1.ClusterRole{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete clusterrole")
+	}
+
+	return result, nil
+}
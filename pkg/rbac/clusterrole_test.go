@@ -0,0 +1,232 @@
+package rbac_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/rbac"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestGenerateClusterRole(t *testing.T) {
+	t.Run("generate empty clusterrole", func(t *testing.T) {
+		expected := &rbacv1.ClusterRole{TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRole",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		}}
+
+		result, err := rbac.GenerateClusterRole(rbac.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := rbac.GenerateClusterRole(rbac.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate rules", func(t *testing.T) {
+		result, err := rbac.GenerateClusterRole(rbac.Conf{
+			GenRulesFunc: func(interfaces.Object) ([]rbacv1.PolicyRule, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate aggregation rule", func(t *testing.T) {
+		result, err := rbac.GenerateClusterRole(rbac.Conf{
+			GenAggregationRuleFunc: func(interfaces.Object) (*rbacv1.AggregationRule, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate clusterrole without namespace", func(t *testing.T) {
+		expected := &rbacv1.ClusterRole{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ClusterRole",
+				APIVersion: "rbac.authorization.k8s.io/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Rules:      testRules,
+		}
+
+		result, err := rbac.GenerateClusterRole(rbac.Conf{
+			Name:         "test",
+			Namespace:    "test",
+			GenRulesFunc: testRulesFunc,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdateClusterRole(t *testing.T) {
+	aggregationRule := &rbacv1.AggregationRule{ClusterRoleSelectors: []metav1.LabelSelector{{
+		MatchLabels: map[string]string{"rbac.example.com/aggregate-to-test": "true"},
+	}}}
+
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateClusterRole(&mocks.MockObject{}, &rbacv1.ClusterRole{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateClusterRole(&rbacv1.ClusterRole{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare clusterroles", func(t *testing.T) {
+		t.Run("empty clusterroles", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateClusterRole(&rbacv1.ClusterRole{}, &rbacv1.ClusterRole{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different rules", func(t *testing.T) {
+			existingClusterRole := &rbacv1.ClusterRole{}
+			newClusterRole := &rbacv1.ClusterRole{Rules: testRules}
+
+			result, err := rbac.MaybeUpdateClusterRole(existingClusterRole, newClusterRole)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingClusterRole, newClusterRole)
+		})
+		t.Run("aggregated rules are ignored", func(t *testing.T) {
+			existingClusterRole := &rbacv1.ClusterRole{Rules: testRules, AggregationRule: aggregationRule}
+			newClusterRole := &rbacv1.ClusterRole{AggregationRule: aggregationRule}
+
+			result, err := rbac.MaybeUpdateClusterRole(existingClusterRole, newClusterRole)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("removed aggregation rule", func(t *testing.T) {
+			existingClusterRole := &rbacv1.ClusterRole{Rules: testRules, AggregationRule: aggregationRule}
+			newClusterRole := &rbacv1.ClusterRole{Rules: testRules}
+
+			result, err := rbac.MaybeUpdateClusterRole(existingClusterRole, newClusterRole)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingClusterRole, newClusterRole)
+		})
+	})
+}
+
+func TestCreateClusterRole(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.CreateClusterRole(rbac.Conf{GenClusterRoleFunc: func(rbac.Conf) (*rbacv1.ClusterRole, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("namespaced owner", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.CreateClusterRole(rbac.Conf{
+			Name:           "test",
+			Instance:       i,
+			Reconcile:      r,
+			OwnerReference: true,
+			GenRulesFunc:   testRulesFunc,
+		})
+		assert.NoError(t, err)
+
+		result := &rbacv1.ClusterRole{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test"}, result)
+		assert.NoError(t, err)
+		assert.Empty(t, result.OwnerReferences)
+	})
+	t.Run("cluster-scoped owner", func(t *testing.T) {
+		i, r := mockSetup(controller, "")
+		_, err := rbac.CreateClusterRole(rbac.Conf{
+			Name:           "test",
+			Instance:       i,
+			Reconcile:      r,
+			OwnerReference: true,
+			GenRulesFunc:   testRulesFunc,
+		})
+		assert.NoError(t, err)
+
+		result := &rbacv1.ClusterRole{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test"}, result)
+		assert.NoError(t, err)
+		assert.Len(t, result.OwnerReferences, 1)
+	})
+}
+
+func TestUpdateClusterRole(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.UpdateClusterRole(rbac.Conf{GenClusterRoleFunc: func(rbac.Conf) (*rbacv1.ClusterRole, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("update clusterrole", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.UpdateClusterRole(rbac.Conf{
+			Name:      "test-existing-clusterrole",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateOrUpdateClusterRole(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.CreateOrUpdateClusterRole(rbac.Conf{GenClusterRoleFunc: func(rbac.Conf) (*rbacv1.ClusterRole, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("create or update clusterrole", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.CreateOrUpdateClusterRole(rbac.Conf{
+			Name:         "test-existing-clusterrole",
+			Instance:     i,
+			Reconcile:    r,
+			GenRulesFunc: testRulesFunc,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDeleteClusterRole(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.DeleteClusterRole(rbac.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("delete clusterrole", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.DeleteClusterRole(rbac.Conf{
+			Name:      "test-existing-clusterrole",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
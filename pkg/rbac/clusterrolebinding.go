@@ -0,0 +1,249 @@
+package rbac
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateClusterRoleBinding generates ClusterRoleBinding object as
+// per the `Conf` struct passed. Namespace from `Conf` is ignored
+// since ClusterRoleBinding is cluster-scoped.
+func GenerateClusterRoleBinding(c Conf) (crb *rbacv1.ClusterRoleBinding, err error) {
+	var om *metav1.ObjectMeta
+	var subjects []rbacv1.Subject
+	var roleRef rbacv1.RoleRef
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenSubjectsFunc != nil {
+		subjects, err = c.GenSubjectsFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate subjects")
+		}
+	}
+
+	if c.GenRoleRefFunc != nil {
+		roleRef, err = c.GenRoleRefFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate role reference")
+		}
+	}
+
+	crb = &rbacv1.ClusterRoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: *om,
+		Subjects:   subjects,
+		RoleRef:    roleRef,
+	}
+
+	return crb, nil
+}
+
+// MaybeUpdateClusterRoleBinding implements MaybeUpdateFunc for
+// ClusterRoleBinding object. It compares the subjects of the two
+// ClusterRoleBindings being passed and update the first one if
+// required. RoleRef is immutable, so it returns
+// operation.ImmutableFieldError if that is different.
+func MaybeUpdateClusterRoleBinding(original interfaces.Object, new interfaces.Object) (bool, error) {
+	ocrb, ok := original.(*rbacv1.ClusterRoleBinding)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	ncrb, ok := new.(*rbacv1.ClusterRoleBinding)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	if !equality.Semantic.DeepEqual(ocrb.RoleRef, ncrb.RoleRef) {
+		return false, operation.NewImmutableFieldError("ClusterRoleBinding", "roleRef")
+	}
+
+	if equalSubjects(ocrb.Subjects, ncrb.Subjects) {
+		return false, nil
+	}
+
+	ocrb.Subjects = ncrb.Subjects
+
+	return true, nil
+}
+
+// CreateClusterRoleBinding generates the ClusterRoleBinding as per
+// the `Conf` struct passed and creates it in the cluster. Owner
+// reference is only set if the owner is cluster-scoped.
+func CreateClusterRoleBinding(c Conf) (reconcile.Result, error) {
+	var crb *rbacv1.ClusterRoleBinding
+	var err error
+	if c.GenClusterRoleBindingFunc != nil {
+		crb, err = c.GenClusterRoleBindingFunc(c)
+	} else {
+		crb, err = GenerateClusterRoleBinding(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate clusterrolebinding")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          crb,
+		OwnerReference:  clusterOwnerReference(c, "ClusterRoleBinding"),
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create clusterrolebinding")
+	}
+
+	return result, nil
+}
+
+// UpdateClusterRoleBinding generates the ClusterRoleBinding as per
+// the `Conf` struct passed and compares it with the in-cluster
+// version. If required, it updates the in-cluster ClusterRoleBinding
+// with the changes. For comparing the ClusterRoleBindings, it uses
+// `MaybeUpdateClusterRoleBinding` function by default but can also
+// use `MaybeUpdateFunc` from `Conf` if passed. If the comparison
+// reports a change in an immutable field, the in-cluster
+// ClusterRoleBinding is deleted and created again.
+func UpdateClusterRoleBinding(c Conf) (reconcile.Result, error) {
+	var crb *rbacv1.ClusterRoleBinding
+	var err error
+	if c.GenClusterRoleBindingFunc != nil {
+		crb, err = c.GenClusterRoleBindingFunc(c)
+	} else {
+		crb, err = GenerateClusterRoleBinding(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate clusterrolebinding")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdateClusterRoleBinding
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          crb,
+		ExistingObject:  &rbacv1.ClusterRoleBinding{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if operation.IsImmutableFieldError(err) {
+		result, err = operation.Recreate(operation.Conf{
+			Instance:        c.Instance,
+			Reconcile:       c.Reconcile,
+			Object:          crb,
+			OwnerReference:  clusterOwnerReference(c, "ClusterRoleBinding"),
+			AfterCreateFunc: c.AfterCreateFunc,
+		})
+		return result, errors.Wrap(err, "failed to recreate clusterrolebinding")
+	}
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update clusterrolebinding")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdateClusterRoleBinding is a combination of
+// `CreateClusterRoleBinding` and `UpdateClusterRoleBinding`
+// functions. It creates the ClusterRoleBinding object if it is not
+// already in the cluster and updates the ClusterRoleBinding if one
+// exists.
+func CreateOrUpdateClusterRoleBinding(c Conf) (reconcile.Result, error) {
+	var crb *rbacv1.ClusterRoleBinding
+	var err error
+	if c.GenClusterRoleBindingFunc != nil {
+		crb, err = c.GenClusterRoleBindingFunc(c)
+	} else {
+		crb, err = GenerateClusterRoleBinding(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate clusterrolebinding")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdateClusterRoleBinding
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          crb,
+		ExistingObject:  &rbacv1.ClusterRoleBinding{},
+		OwnerReference:  clusterOwnerReference(c, "ClusterRoleBinding"),
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if operation.IsImmutableFieldError(err) {
+		result, err = operation.Recreate(operation.Conf{
+			Instance:        c.Instance,
+			Reconcile:       c.Reconcile,
+			Object:          crb,
+			OwnerReference:  clusterOwnerReference(c, "ClusterRoleBinding"),
+			AfterCreateFunc: c.AfterCreateFunc,
+		})
+		return result, errors.Wrap(err, "failed to recreate clusterrolebinding")
+	}
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update clusterrolebinding")
+	}
+
+	return result, nil
+}
+
+// DeleteClusterRoleBinding generates the ObjectMeta for
+// ClusterRoleBinding as per the `Conf` struct passed and deletes it
+// from the cluster
+func DeleteClusterRoleBinding(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for clusterrolebinding")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &rbacv1.ClusterRoleBinding{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete clusterrolebinding")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,233 @@
+package rbac_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+	"github.com/ankitrgadiya/operatorlib/pkg/rbac"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestGenerateClusterRoleBinding(t *testing.T) {
+	t.Run("generate empty clusterrolebinding", func(t *testing.T) {
+		expected := &rbacv1.ClusterRoleBinding{TypeMeta: metav1.TypeMeta{
+			Kind:       "ClusterRoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		}}
+
+		result, err := rbac.GenerateClusterRoleBinding(rbac.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate subjects", func(t *testing.T) {
+		result, err := rbac.GenerateClusterRoleBinding(rbac.Conf{
+			GenSubjectsFunc: func(interfaces.Object) ([]rbacv1.Subject, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate role reference", func(t *testing.T) {
+		result, err := rbac.GenerateClusterRoleBinding(rbac.Conf{
+			GenRoleRefFunc: func(interfaces.Object) (rbacv1.RoleRef, error) {
+				return rbacv1.RoleRef{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate clusterrolebinding without namespace", func(t *testing.T) {
+		expected := &rbacv1.ClusterRoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ClusterRoleBinding",
+				APIVersion: "rbac.authorization.k8s.io/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "test"},
+			Subjects:   testSubjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "test"},
+		}
+
+		result, err := rbac.GenerateClusterRoleBinding(rbac.Conf{
+			Name:            "test",
+			Namespace:       "test",
+			GenSubjectsFunc: testSubjectsFunc,
+			GenRoleRefFunc:  testRoleRefFunc("ClusterRole", "test"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdateClusterRoleBinding(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateClusterRoleBinding(&mocks.MockObject{}, &rbacv1.ClusterRoleBinding{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateClusterRoleBinding(&rbacv1.ClusterRoleBinding{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare clusterrolebindings", func(t *testing.T) {
+		t.Run("empty clusterrolebindings", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateClusterRoleBinding(&rbacv1.ClusterRoleBinding{}, &rbacv1.ClusterRoleBinding{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different subjects", func(t *testing.T) {
+			existingClusterRoleBinding := &rbacv1.ClusterRoleBinding{}
+			newClusterRoleBinding := &rbacv1.ClusterRoleBinding{Subjects: testSubjects}
+
+			result, err := rbac.MaybeUpdateClusterRoleBinding(existingClusterRoleBinding, newClusterRoleBinding)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingClusterRoleBinding, newClusterRoleBinding)
+		})
+		t.Run("different role reference", func(t *testing.T) {
+			existingClusterRoleBinding := &rbacv1.ClusterRoleBinding{
+				RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"},
+			}
+			newClusterRoleBinding := &rbacv1.ClusterRoleBinding{
+				RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "edit"},
+			}
+
+			result, err := rbac.MaybeUpdateClusterRoleBinding(existingClusterRoleBinding, newClusterRoleBinding)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+	})
+}
+
+func TestCreateClusterRoleBinding(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.CreateClusterRoleBinding(rbac.Conf{GenClusterRoleBindingFunc: func(rbac.Conf) (*rbacv1.ClusterRoleBinding, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("namespaced owner", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.CreateClusterRoleBinding(rbac.Conf{
+			Name:            "test",
+			Instance:        i,
+			Reconcile:       r,
+			OwnerReference:  true,
+			GenSubjectsFunc: testSubjectsFunc,
+			GenRoleRefFunc:  testRoleRefFunc("ClusterRole", "test-existing-clusterrole"),
+		})
+		assert.NoError(t, err)
+
+		result := &rbacv1.ClusterRoleBinding{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test"}, result)
+		assert.NoError(t, err)
+		assert.Empty(t, result.OwnerReferences)
+	})
+	t.Run("namespaced owner is logged", func(t *testing.T) {
+		i, m := mockSetup(controller, "test")
+		r := &loggerReconcile{MockReconcile: m, logger: messageLogger{messages: &[]string{}}}
+		_, err := rbac.CreateClusterRoleBinding(rbac.Conf{
+			Name:            "test",
+			Instance:        i,
+			Reconcile:       r,
+			OwnerReference:  true,
+			GenSubjectsFunc: testSubjectsFunc,
+			GenRoleRefFunc:  testRoleRefFunc("ClusterRole", "test-existing-clusterrole"),
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, *r.logger.messages, "owner reference is not set as the owner is namespaced")
+	})
+}
+
+func TestUpdateClusterRoleBinding(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.UpdateClusterRoleBinding(rbac.Conf{GenClusterRoleBindingFunc: func(rbac.Conf) (*rbacv1.ClusterRoleBinding, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("recreate clusterrolebinding", func(t *testing.T) {
+		i, r := mockSetup(controller, "")
+		_, err := rbac.UpdateClusterRoleBinding(rbac.Conf{
+			Name:            "test-existing-clusterrolebinding",
+			Instance:        i,
+			Reconcile:       r,
+			OwnerReference:  true,
+			GenSubjectsFunc: testSubjectsFunc,
+			GenRoleRefFunc:  testRoleRefFunc("ClusterRole", "view"),
+		})
+		assert.NoError(t, err)
+
+		result := &rbacv1.ClusterRoleBinding{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-clusterrolebinding"}, result)
+		assert.NoError(t, err)
+		assert.Equal(t, "view", result.RoleRef.Name)
+		assert.Len(t, result.OwnerReferences, 1)
+	})
+}
+
+func TestCreateOrUpdateClusterRoleBinding(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.CreateOrUpdateClusterRoleBinding(rbac.Conf{GenClusterRoleBindingFunc: func(rbac.Conf) (*rbacv1.ClusterRoleBinding, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("recreate clusterrolebinding", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.CreateOrUpdateClusterRoleBinding(rbac.Conf{
+			Name:            "test-existing-clusterrolebinding",
+			Instance:        i,
+			Reconcile:       r,
+			GenSubjectsFunc: testSubjectsFunc,
+			GenRoleRefFunc:  testRoleRefFunc("ClusterRole", "view"),
+		})
+		assert.NoError(t, err)
+
+		result := &rbacv1.ClusterRoleBinding{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-clusterrolebinding"}, result)
+		assert.NoError(t, err)
+		assert.Equal(t, "view", result.RoleRef.Name)
+	})
+}
+
+func TestDeleteClusterRoleBinding(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.DeleteClusterRoleBinding(rbac.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("delete clusterrolebinding", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.DeleteClusterRoleBinding(rbac.Conf{
+			Name:      "test-existing-clusterrolebinding",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
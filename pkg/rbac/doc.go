@@ -0,0 +1,14 @@
+// Package rbac provides functions for manipulating Role, RoleBinding,
+// ClusterRole and ClusterRoleBinding objects in Kubernetes cluster.
+//
+// All four kinds share the same `Conf` struct and every function is
+// suffixed with the kind it operates on, for example `CreateRole` or
+// `DeleteClusterRoleBinding`. RoleRef of the bindings is immutable,
+// so the bindings are deleted and created again if it changes.
+//
+// ClusterRole and ClusterRoleBinding are cluster-scoped and cannot be
+// owned by a namespaced owner. For those kinds the owner reference is
+// only set if the owner is cluster-scoped as well. Otherwise the
+// garbage collector does not clean them up and they have to be
+// deleted explicitly, for example while finalizing the owner.
+package rbac
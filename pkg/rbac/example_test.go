@@ -0,0 +1,72 @@
+package rbac_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/rbac"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdateRole() {
+	result, err := rbac.CreateOrUpdateRole(rbac.Conf{
+		// Instance is the pointer to owner object under which Role
+		// is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the Role object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated Role.
+		Name: "role-test",
+		// GenRulesFunc is the function that generates the rules of
+		// the Role.
+		GenRulesFunc: func(interfaces.Object) ([]rbacv1.PolicyRule, error) {
+			return []rbacv1.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     []string{"get", "list", "watch"},
+			}}, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
+
+func ExampleCreateOrUpdateClusterRoleBinding() {
+	result, err := rbac.CreateOrUpdateClusterRoleBinding(rbac.Conf{
+		Instance:  ownerObject,
+		Reconcile: ownerReconcile,
+		// OwnerReference is only set if the owner object is
+		// cluster-scoped. Otherwise DeleteClusterRoleBinding needs
+		// to be called while finalizing the owner object.
+		OwnerReference: true,
+		Name:           "crb-test",
+		// GenSubjectsFunc is the function that generates the
+		// subjects which are bound to the role.
+		GenSubjectsFunc: func(interfaces.Object) ([]rbacv1.Subject, error) {
+			return []rbacv1.Subject{{
+				Kind:      rbacv1.ServiceAccountKind,
+				Name:      "sa-test",
+				Namespace: "default",
+			}}, nil
+		},
+		// GenRoleRefFunc is the function that generates the role
+		// reference. Changing it deletes and creates the binding
+		// again since the field is immutable.
+		GenRoleRefFunc: func(interfaces.Object) (rbacv1.RoleRef, error) {
+			return rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"}, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
@@ -0,0 +1,44 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/logging"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+// clusterOwnerReference tells if owner reference can be set on the
+// cluster-scoped object of the kind passed. Garbage collector does not
+// allow a namespaced owner for cluster-scoped objects, so it is only
+// set if the owner is cluster-scoped as well. Otherwise, if owner
+// reference is asked for, it is logged that it is not set.
+func clusterOwnerReference(c Conf, kind string) bool {
+	if !c.OwnerReference || c.Instance == nil {
+		return false
+	}
+	if c.Instance.GetNamespace() != "" {
+		logging.Get(context.Background(), c.Reconcile).Info("owner reference is not set as the owner is namespaced",
+			logging.KindKey, kind, logging.NameKey, c.Name)
+		return false
+	}
+	return true
+}
+
+// equalSubjects compares the subjects of the bindings. API Server
+// defaults the APIGroup of the subjects, so only the fields set in the
+// new subjects are compared.
+func equalSubjects(existing []rbacv1.Subject, new []rbacv1.Subject) bool {
+	if len(existing) != len(new) {
+		return false
+	}
+
+	for i := range new {
+		if !equality.Semantic.DeepDerivative(new[i], existing[i]) {
+			return false
+		}
+	}
+
+	return true
+}
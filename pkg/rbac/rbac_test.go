@@ -0,0 +1,106 @@
+package rbac_test
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+
+	"github.com/go-logr/logr"
+	"github.com/golang/mock/gomock"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var testRules = []rbacv1.PolicyRule{{
+	APIGroups: []string{""},
+	Resources: []string{"configmaps"},
+	Verbs:     []string{"get", "list", "watch"},
+}}
+
+var testSubjects = []rbacv1.Subject{{
+	Kind:      rbacv1.ServiceAccountKind,
+	Name:      "test",
+	Namespace: "test",
+}}
+
+func testRulesFunc(interfaces.Object) ([]rbacv1.PolicyRule, error) { return testRules, nil }
+
+func testSubjectsFunc(interfaces.Object) ([]rbacv1.Subject, error) { return testSubjects, nil }
+
+func testRoleRefFunc(kind string, name string) func(interfaces.Object) (rbacv1.RoleRef, error) {
+	return func(interfaces.Object) (rbacv1.RoleRef, error) {
+		return rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: kind, Name: name}, nil
+	}
+}
+
+// mockSetup creates the mocks with an owner object in the namespace
+// passed. Empty namespace makes the owner cluster-scoped.
+func mockSetup(ctrl *gomock.Controller, namespace string) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetNamespace().Return(namespace).AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+
+	objects := []runtime.Object{
+		&rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-role", Namespace: "test"},
+			Rules:      testRules,
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-rolebinding", Namespace: "test"},
+			Subjects:   testSubjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "test-existing-role"},
+		},
+		&rbacv1.ClusterRole{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-clusterrole"},
+			Rules:      testRules,
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-existing-clusterrolebinding"},
+			Subjects:   testSubjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "test-existing-clusterrole"},
+		},
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient(objects...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+// messageLogger records the messages of the logs written to it.
+type messageLogger struct {
+	messages *[]string
+}
+
+func (l messageLogger) Info(msg string, _ ...interface{}) { *l.messages = append(*l.messages, msg) }
+
+func (l messageLogger) Enabled() bool { return true }
+
+func (l messageLogger) Error(_ error, msg string, _ ...interface{}) {
+	*l.messages = append(*l.messages, msg)
+}
+
+func (l messageLogger) V(int) logr.InfoLogger { return l }
+
+func (l messageLogger) WithValues(...interface{}) logr.Logger { return l }
+
+func (l messageLogger) WithName(string) logr.Logger { return l }
+
+// loggerReconcile is the reconcile which also implements
+// interfaces.Logger.
+type loggerReconcile struct {
+	*mocks.MockReconcile
+	logger messageLogger
+}
+
+func (r *loggerReconcile) GetLogger() logr.Logger { return r.logger }
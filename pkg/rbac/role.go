@@ -0,0 +1,207 @@
+package rbac
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateRole generates Role object as per the `Conf` struct passed.
+func GenerateRole(c Conf) (r *rbacv1.Role, err error) {
+	var om *metav1.ObjectMeta
+	var rules []rbacv1.PolicyRule
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenRulesFunc != nil {
+		rules, err = c.GenRulesFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate rules")
+		}
+	}
+
+	r = &rbacv1.Role{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: *om,
+		Rules:      rules,
+	}
+
+	return r, nil
+}
+
+// MaybeUpdateRole implements MaybeUpdateFunc for Role object. It
+// compares the rules of the two Roles being passed and update the
+// first one if required.
+func MaybeUpdateRole(original interfaces.Object, new interfaces.Object) (bool, error) {
+	or, ok := original.(*rbacv1.Role)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	nr, ok := new.(*rbacv1.Role)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	if equality.Semantic.DeepEqual(or.Rules, nr.Rules) {
+		return false, nil
+	}
+
+	or.Rules = nr.Rules
+
+	return true, nil
+}
+
+// CreateRole generates the Role as per the `Conf` struct passed and
+// creates it in the cluster
+func CreateRole(c Conf) (reconcile.Result, error) {
+	var r *rbacv1.Role
+	var err error
+	if c.GenRoleFunc != nil {
+		r, err = c.GenRoleFunc(c)
+	} else {
+		r, err = GenerateRole(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate role")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          r,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create role")
+	}
+
+	return result, nil
+}
+
+// UpdateRole generates the Role as per the `Conf` struct passed and
+// compares it with the in-cluster version. If required, it updates
+// the in-cluster Role with the changes. For comparing the Roles, it
+// uses `MaybeUpdateRole` function by default but can also use
+// `MaybeUpdateFunc` from `Conf` if passed.
+func UpdateRole(c Conf) (reconcile.Result, error) {
+	var r *rbacv1.Role
+	var err error
+	if c.GenRoleFunc != nil {
+		r, err = c.GenRoleFunc(c)
+	} else {
+		r, err = GenerateRole(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate role")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdateRole
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          r,
+		ExistingObject:  &rbacv1.Role{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update role")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdateRole is a combination of `CreateRole` and
+// `UpdateRole` functions. It creates the Role object if it is not
+// already in the cluster and updates the Role if one exists.
+func CreateOrUpdateRole(c Conf) (reconcile.Result, error) {
+	var r *rbacv1.Role
+	var err error
+	if c.GenRoleFunc != nil {
+		r, err = c.GenRoleFunc(c)
+	} else {
+		r, err = GenerateRole(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate role")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdateRole
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          r,
+		ExistingObject:  &rbacv1.Role{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update role")
+	}
+
+	return result, nil
+}
+
+// DeleteRole generates the ObjectMeta for Role as per the `Conf`
+// struct passed and deletes it from the cluster
+func DeleteRole(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for role")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &rbacv1.Role{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete role")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,218 @@
+package rbac_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/rbac"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestGenerateRole(t *testing.T) {
+	t.Run("generate empty role", func(t *testing.T) {
+		expected := &rbacv1.Role{TypeMeta: metav1.TypeMeta{
+			Kind:       "Role",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		}}
+
+		result, err := rbac.GenerateRole(rbac.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := rbac.GenerateRole(rbac.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate rules", func(t *testing.T) {
+		result, err := rbac.GenerateRole(rbac.Conf{
+			GenRulesFunc: func(interfaces.Object) ([]rbacv1.PolicyRule, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate role", func(t *testing.T) {
+		expected := &rbacv1.Role{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "Role",
+				APIVersion: "rbac.authorization.k8s.io/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Rules:      testRules,
+		}
+
+		result, err := rbac.GenerateRole(rbac.Conf{
+			Name:         "test",
+			Namespace:    "test",
+			GenRulesFunc: testRulesFunc,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdateRole(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateRole(&mocks.MockObject{}, &rbacv1.Role{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateRole(&rbacv1.Role{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare roles", func(t *testing.T) {
+		t.Run("empty roles", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateRole(&rbacv1.Role{}, &rbacv1.Role{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("same rules", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateRole(&rbacv1.Role{Rules: testRules}, &rbacv1.Role{Rules: testRules})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different rules", func(t *testing.T) {
+			existingRole := &rbacv1.Role{Rules: testRules}
+			newRole := &rbacv1.Role{Rules: []rbacv1.PolicyRule{{
+				APIGroups: []string{""},
+				Resources: []string{"secrets"},
+				Verbs:     []string{"get"},
+			}}}
+
+			result, err := rbac.MaybeUpdateRole(existingRole, newRole)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingRole, newRole)
+		})
+	})
+}
+
+func TestCreateRole(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.CreateRole(rbac.Conf{GenRulesFunc: func(interfaces.Object) ([]rbacv1.PolicyRule, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := rbac.CreateRole(rbac.Conf{GenRoleFunc: func(rbac.Conf) (*rbacv1.Role, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.CreateRole(rbac.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create role", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.CreateRole(rbac.Conf{
+			Instance:     i,
+			Reconcile:    r,
+			GenRulesFunc: testRulesFunc,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdateRole(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.UpdateRole(rbac.Conf{GenRoleFunc: func(rbac.Conf) (*rbacv1.Role, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.UpdateRole(rbac.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("update role", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.UpdateRole(rbac.Conf{
+			Name:      "test-existing-role",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateOrUpdateRole(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.CreateOrUpdateRole(rbac.Conf{GenRoleFunc: func(rbac.Conf) (*rbacv1.Role, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("create or update role", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.CreateOrUpdateRole(rbac.Conf{
+			Name:         "test-existing-role",
+			Namespace:    "test",
+			Instance:     i,
+			Reconcile:    r,
+			GenRulesFunc: testRulesFunc,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDeleteRole(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.DeleteRole(rbac.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("delete role", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.DeleteRole(rbac.Conf{
+			Name:      "test-existing-role",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,246 @@
+package rbac
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateRoleBinding generates RoleBinding object as per the `Conf`
+// struct passed.
+func GenerateRoleBinding(c Conf) (rb *rbacv1.RoleBinding, err error) {
+	var om *metav1.ObjectMeta
+	var subjects []rbacv1.Subject
+	var roleRef rbacv1.RoleRef
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenSubjectsFunc != nil {
+		subjects, err = c.GenSubjectsFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate subjects")
+		}
+	}
+
+	if c.GenRoleRefFunc != nil {
+		roleRef, err = c.GenRoleRefFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate role reference")
+		}
+	}
+
+	rb = &rbacv1.RoleBinding{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		},
+		ObjectMeta: *om,
+		Subjects:   subjects,
+		RoleRef:    roleRef,
+	}
+
+	return rb, nil
+}
+
+// MaybeUpdateRoleBinding implements MaybeUpdateFunc for RoleBinding
+// object. It compares the subjects of the two RoleBindings being
+// passed and update the first one if required. RoleRef is immutable,
+// so it returns operation.ImmutableFieldError if that is different.
+func MaybeUpdateRoleBinding(original interfaces.Object, new interfaces.Object) (bool, error) {
+	orb, ok := original.(*rbacv1.RoleBinding)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	nrb, ok := new.(*rbacv1.RoleBinding)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	if !equality.Semantic.DeepEqual(orb.RoleRef, nrb.RoleRef) {
+		return false, operation.NewImmutableFieldError("RoleBinding", "roleRef")
+	}
+
+	if equalSubjects(orb.Subjects, nrb.Subjects) {
+		return false, nil
+	}
+
+	orb.Subjects = nrb.Subjects
+
+	return true, nil
+}
+
+// CreateRoleBinding generates the RoleBinding as per the `Conf`
+// struct passed and creates it in the cluster
+func CreateRoleBinding(c Conf) (reconcile.Result, error) {
+	var rb *rbacv1.RoleBinding
+	var err error
+	if c.GenRoleBindingFunc != nil {
+		rb, err = c.GenRoleBindingFunc(c)
+	} else {
+		rb, err = GenerateRoleBinding(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate rolebinding")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          rb,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create rolebinding")
+	}
+
+	return result, nil
+}
+
+// UpdateRoleBinding generates the RoleBinding as per the `Conf`
+// struct passed and compares it with the in-cluster version. If
+// required, it updates the in-cluster RoleBinding with the
+// changes. For comparing the RoleBindings, it uses
+// `MaybeUpdateRoleBinding` function by default but can also use
+// `MaybeUpdateFunc` from `Conf` if passed. If the comparison reports
+// a change in an immutable field, the in-cluster RoleBinding is
+// deleted and created again.
+func UpdateRoleBinding(c Conf) (reconcile.Result, error) {
+	var rb *rbacv1.RoleBinding
+	var err error
+	if c.GenRoleBindingFunc != nil {
+		rb, err = c.GenRoleBindingFunc(c)
+	} else {
+		rb, err = GenerateRoleBinding(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate rolebinding")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdateRoleBinding
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          rb,
+		ExistingObject:  &rbacv1.RoleBinding{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if operation.IsImmutableFieldError(err) {
+		result, err = operation.Recreate(operation.Conf{
+			Instance:        c.Instance,
+			Reconcile:       c.Reconcile,
+			Object:          rb,
+			OwnerReference:  c.OwnerReference,
+			AfterCreateFunc: c.AfterCreateFunc,
+		})
+		return result, errors.Wrap(err, "failed to recreate rolebinding")
+	}
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update rolebinding")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdateRoleBinding is a combination of `CreateRoleBinding`
+// and `UpdateRoleBinding` functions. It creates the RoleBinding
+// object if it is not already in the cluster and updates the
+// RoleBinding if one exists.
+func CreateOrUpdateRoleBinding(c Conf) (reconcile.Result, error) {
+	var rb *rbacv1.RoleBinding
+	var err error
+	if c.GenRoleBindingFunc != nil {
+		rb, err = c.GenRoleBindingFunc(c)
+	} else {
+		rb, err = GenerateRoleBinding(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate rolebinding")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdateRoleBinding
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          rb,
+		ExistingObject:  &rbacv1.RoleBinding{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if operation.IsImmutableFieldError(err) {
+		result, err = operation.Recreate(operation.Conf{
+			Instance:        c.Instance,
+			Reconcile:       c.Reconcile,
+			Object:          rb,
+			OwnerReference:  c.OwnerReference,
+			AfterCreateFunc: c.AfterCreateFunc,
+		})
+		return result, errors.Wrap(err, "failed to recreate rolebinding")
+	}
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update rolebinding")
+	}
+
+	return result, nil
+}
+
+// DeleteRoleBinding generates the ObjectMeta for RoleBinding as per
+// the `Conf` struct passed and deletes it from the cluster
+func DeleteRoleBinding(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for rolebinding")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &rbacv1.RoleBinding{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete rolebinding")
+	}
+
+	return result, nil
+}
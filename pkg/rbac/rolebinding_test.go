@@ -0,0 +1,270 @@
+package rbac_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+	"github.com/ankitrgadiya/operatorlib/pkg/rbac"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestGenerateRoleBinding(t *testing.T) {
+	t.Run("generate empty rolebinding", func(t *testing.T) {
+		expected := &rbacv1.RoleBinding{TypeMeta: metav1.TypeMeta{
+			Kind:       "RoleBinding",
+			APIVersion: "rbac.authorization.k8s.io/v1",
+		}}
+
+		result, err := rbac.GenerateRoleBinding(rbac.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := rbac.GenerateRoleBinding(rbac.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate subjects", func(t *testing.T) {
+		result, err := rbac.GenerateRoleBinding(rbac.Conf{
+			GenSubjectsFunc: func(interfaces.Object) ([]rbacv1.Subject, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate role reference", func(t *testing.T) {
+		result, err := rbac.GenerateRoleBinding(rbac.Conf{
+			GenRoleRefFunc: func(interfaces.Object) (rbacv1.RoleRef, error) {
+				return rbacv1.RoleRef{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate rolebinding", func(t *testing.T) {
+		expected := &rbacv1.RoleBinding{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "RoleBinding",
+				APIVersion: "rbac.authorization.k8s.io/v1",
+			},
+			ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test"},
+			Subjects:   testSubjects,
+			RoleRef:    rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "test"},
+		}
+
+		result, err := rbac.GenerateRoleBinding(rbac.Conf{
+			Name:            "test",
+			Namespace:       "test",
+			GenSubjectsFunc: testSubjectsFunc,
+			GenRoleRefFunc:  testRoleRefFunc("Role", "test"),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdateRoleBinding(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateRoleBinding(&mocks.MockObject{}, &rbacv1.RoleBinding{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateRoleBinding(&rbacv1.RoleBinding{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare rolebindings", func(t *testing.T) {
+		t.Run("empty rolebindings", func(t *testing.T) {
+			result, err := rbac.MaybeUpdateRoleBinding(&rbacv1.RoleBinding{}, &rbacv1.RoleBinding{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("defaulted subjects are ignored", func(t *testing.T) {
+			existingRoleBinding := &rbacv1.RoleBinding{Subjects: []rbacv1.Subject{{
+				Kind:     rbacv1.UserKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "test",
+			}}}
+			newRoleBinding := &rbacv1.RoleBinding{Subjects: []rbacv1.Subject{{
+				Kind: rbacv1.UserKind,
+				Name: "test",
+			}}}
+
+			result, err := rbac.MaybeUpdateRoleBinding(existingRoleBinding, newRoleBinding)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("removed subject", func(t *testing.T) {
+			existingRoleBinding := &rbacv1.RoleBinding{Subjects: append([]rbacv1.Subject{{
+				Kind: rbacv1.UserKind,
+				Name: "test",
+			}}, testSubjects...)}
+			newRoleBinding := &rbacv1.RoleBinding{Subjects: testSubjects}
+
+			result, err := rbac.MaybeUpdateRoleBinding(existingRoleBinding, newRoleBinding)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingRoleBinding, newRoleBinding)
+		})
+		t.Run("different role reference", func(t *testing.T) {
+			existingRoleBinding := &rbacv1.RoleBinding{
+				RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: "test"},
+			}
+			newRoleBinding := &rbacv1.RoleBinding{
+				RoleRef: rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "test"},
+			}
+
+			result, err := rbac.MaybeUpdateRoleBinding(existingRoleBinding, newRoleBinding)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+	})
+}
+
+func TestCreateRoleBinding(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.CreateRoleBinding(rbac.Conf{GenSubjectsFunc: func(interfaces.Object) ([]rbacv1.Subject, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := rbac.CreateRoleBinding(rbac.Conf{GenRoleBindingFunc: func(rbac.Conf) (*rbacv1.RoleBinding, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("create rolebinding", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.CreateRoleBinding(rbac.Conf{
+			Instance:        i,
+			Reconcile:       r,
+			GenSubjectsFunc: testSubjectsFunc,
+			GenRoleRefFunc:  testRoleRefFunc("Role", "test"),
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdateRoleBinding(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.UpdateRoleBinding(rbac.Conf{GenRoleBindingFunc: func(rbac.Conf) (*rbacv1.RoleBinding, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("update rolebinding", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.UpdateRoleBinding(rbac.Conf{
+			Name:           "test-existing-rolebinding",
+			Namespace:      "test",
+			Instance:       i,
+			Reconcile:      r,
+			GenRoleRefFunc: testRoleRefFunc("Role", "test-existing-role"),
+		})
+		assert.NoError(t, err)
+
+		result := &rbacv1.RoleBinding{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-rolebinding", Namespace: "test"}, result)
+		assert.NoError(t, err)
+		assert.Empty(t, result.Subjects)
+	})
+	t.Run("recreate rolebinding", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.UpdateRoleBinding(rbac.Conf{
+			Name:            "test-existing-rolebinding",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			GenSubjectsFunc: testSubjectsFunc,
+			GenRoleRefFunc:  testRoleRefFunc("ClusterRole", "view"),
+		})
+		assert.NoError(t, err)
+
+		result := &rbacv1.RoleBinding{}
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-rolebinding", Namespace: "test"}, result)
+		assert.NoError(t, err)
+		assert.Equal(t, "view", result.RoleRef.Name)
+	})
+}
+
+func TestCreateOrUpdateRoleBinding(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.CreateOrUpdateRoleBinding(rbac.Conf{GenRoleBindingFunc: func(rbac.Conf) (*rbacv1.RoleBinding, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to recreate", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.CreateOrUpdateRoleBinding(rbac.Conf{
+			Name:           "test-existing-rolebinding",
+			Namespace:      "test",
+			Instance:       i,
+			Reconcile:      r,
+			GenRoleRefFunc: testRoleRefFunc("ClusterRole", "view"),
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update rolebinding", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.CreateOrUpdateRoleBinding(rbac.Conf{
+			Name:            "test-existing-rolebinding",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			GenSubjectsFunc: testSubjectsFunc,
+			GenRoleRefFunc:  testRoleRefFunc("Role", "test-existing-role"),
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDeleteRoleBinding(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := rbac.DeleteRoleBinding(rbac.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("delete rolebinding", func(t *testing.T) {
+		i, r := mockSetup(controller, "test")
+		_, err := rbac.DeleteRoleBinding(rbac.Conf{
+			Name:      "test-existing-rolebinding",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,108 @@
+package rbac
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// GenRoleFunc defines a function which generates Role
+type GenRoleFunc func(Conf) (*rbacv1.Role, error)
+
+// GenRoleBindingFunc defines a function which generates RoleBinding
+type GenRoleBindingFunc func(Conf) (*rbacv1.RoleBinding, error)
+
+// GenClusterRoleFunc defines a function which generates ClusterRole
+type GenClusterRoleFunc func(Conf) (*rbacv1.ClusterRole, error)
+
+// GenClusterRoleBindingFunc defines a function which generates
+// ClusterRoleBinding
+type GenClusterRoleBindingFunc func(Conf) (*rbacv1.ClusterRoleBinding, error)
+
+// GenRulesFunc defines a function which generates slice of
+// PolicyRule for Role or ClusterRole
+type GenRulesFunc func(interfaces.Object) ([]rbacv1.PolicyRule, error)
+
+// GenAggregationRuleFunc defines a function which generates
+// AggregationRule for ClusterRole
+type GenAggregationRuleFunc func(interfaces.Object) (*rbacv1.AggregationRule, error)
+
+// GenSubjectsFunc defines a function which generates slice of Subject
+// for RoleBinding or ClusterRoleBinding
+type GenSubjectsFunc func(interfaces.Object) ([]rbacv1.Subject, error)
+
+// GenRoleRefFunc defines a function which generates RoleRef for
+// RoleBinding or ClusterRoleBinding
+type GenRoleRefFunc func(interfaces.Object) (rbacv1.RoleRef, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on Role, RoleBinding, ClusterRole and
+// ClusterRoleBinding objects. Fields which do not apply to the kind
+// being operated on are ignored.
+type Conf struct {
+	// Instance is the Owner object which manages the object
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the object
+	Name string
+	// Namespace of the object. It is ignored for ClusterRole and
+	// ClusterRoleBinding.
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on the object before creating it in cluster. For
+	// ClusterRole and ClusterRoleBinding it is only set if the owner
+	// is cluster-scoped, as garbage collector does not allow
+	// namespaced owners for them. It is logged if it is not set
+	// because of that, and the objects must then be deleted while
+	// finalizing the owner.
+	OwnerReference bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for the object update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the object
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the object
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the object
+	operation.AfterDeleteFunc
+	// GenRoleFunc defines a function to generate the Role object. The
+	// package comes with default generator function which is used by
+	// operation functions. By specifying this field, user can
+	// override the default function with a custom one.
+	GenRoleFunc
+	// GenRoleBindingFunc defines a function to generate the
+	// RoleBinding object, overriding the default generator function.
+	GenRoleBindingFunc
+	// GenClusterRoleFunc defines a function to generate the
+	// ClusterRole object, overriding the default generator function.
+	GenClusterRoleFunc
+	// GenClusterRoleBindingFunc defines a function to generate the
+	// ClusterRoleBinding object, overriding the default generator
+	// function.
+	GenClusterRoleBindingFunc
+	// GenRulesFunc defines a function to generate rules for Role and
+	// ClusterRole
+	GenRulesFunc
+	// GenAggregationRuleFunc defines a function to generate
+	// aggregation rule for ClusterRole
+	GenAggregationRuleFunc
+	// GenSubjectsFunc defines a function to generate subjects for
+	// RoleBinding and ClusterRoleBinding
+	GenSubjectsFunc
+	// GenRoleRefFunc defines a function to generate role reference
+	// for RoleBinding and ClusterRoleBinding
+	GenRoleRefFunc
+}
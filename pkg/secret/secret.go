@@ -1,6 +1,7 @@
 package secret
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
@@ -13,6 +14,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+// redactPaths are the fields of Secret whose values are redacted in
+// the diffs.
+var redactPaths = []string{"/data", "/stringData"}
+
 // GenerateSecret generates Secret object as per the `Conf` struct
 // passed. However, this does one special thing while generating
 // Secret. StringData is merged into Data because of how Secrets are
@@ -96,9 +101,38 @@ func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error
 	return true, nil
 }
 
+// MaybeUpdateWithDiff implements MaybeUpdateWithDiffFunc for Secret
+// object. It updates the first Secret like MaybeUpdate and returns the
+// fields changed, with the values of the data redacted.
+func MaybeUpdateWithDiff(original interfaces.Object, new interfaces.Object) ([]operation.FieldDiff, error) {
+	o, ok := original.(*corev1.Secret)
+	if !ok {
+		return nil, errors.New("failed to assert the original object")
+	}
+	before := o.DeepCopy()
+
+	update, err := MaybeUpdate(original, new)
+	if err != nil || !update {
+		return nil, err
+	}
+
+	diff, err := operation.Diff(before, original)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute the diff of secret")
+	}
+
+	return operation.Redact(diff, redactPaths...), nil
+}
+
 // Create generates Secret as per the `Conf` struct passed and creates
 // it in the cluster
 func Create(c Conf) (reconcile.Result, error) {
+	return CreateWithContext(context.Background(), c)
+}
+
+// CreateWithContext is same as Create but uses the context passed for
+// the calls to API Server and the hooks.
+func CreateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	var s *corev1.Secret
 	var err error
 	if c.GenSecretFunc != nil {
@@ -110,12 +144,19 @@ func Create(c Conf) (reconcile.Result, error) {
 		return reconcile.Result{}, errors.Wrap(err, "failed to generate secret")
 	}
 
-	result, err := operation.Create(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          s,
-		OwnerReference:  c.OwnerReference,
-		AfterCreateFunc: c.AfterCreateFunc,
+	result, err := operation.CreateWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Logger:                     c.Logger,
+		RedactPaths:                redactPaths,
+		Object:                     s,
+		OwnerReference:             c.OwnerReference,
+		AfterCreateFunc:            c.AfterCreateFunc,
+		AfterCreateWithContextFunc: c.AfterCreateWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to create secret")
@@ -129,6 +170,12 @@ func Create(c Conf) (reconcile.Result, error) {
 // it uses `MaybeUpdate` function by default but can also use
 // `MaybeUpdateFunc` from `Conf` if passed.
 func Update(c Conf) (reconcile.Result, error) {
+	return UpdateWithContext(context.Background(), c)
+}
+
+// UpdateWithContext is same as Update but uses the context passed for
+// the calls to API Server and the hooks.
+func UpdateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	var s *corev1.Secret
 	var err error
 	if c.GenSecretFunc != nil {
@@ -147,13 +194,27 @@ func Update(c Conf) (reconcile.Result, error) {
 		maybeUpdateFunc = MaybeUpdate
 	}
 
-	result, err := operation.Update(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          s,
-		ExistingObject:  &corev1.Secret{},
-		MaybeUpdateFunc: maybeUpdateFunc,
-		AfterUpdateFunc: c.AfterUpdateFunc,
+	maybeUpdateWithDiffFunc := c.MaybeUpdateWithDiffFunc
+	if maybeUpdateWithDiffFunc == nil && c.MaybeUpdateFunc == nil {
+		maybeUpdateWithDiffFunc = MaybeUpdateWithDiff
+	}
+
+	result, err := operation.UpdateWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Object:                     s,
+		ExistingObject:             &corev1.Secret{},
+		MaybeUpdateFunc:            maybeUpdateFunc,
+		MaybeUpdateWithDiffFunc:    maybeUpdateWithDiffFunc,
+		DiffHook:                   c.DiffHook,
+		Logger:                     c.Logger,
+		RedactPaths:                redactPaths,
+		AfterUpdateFunc:            c.AfterUpdateFunc,
+		AfterUpdateWithContextFunc: c.AfterUpdateWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to update secret")
@@ -166,6 +227,12 @@ func Update(c Conf) (reconcile.Result, error) {
 // functions. It creates the Secret object if it is not already in the
 // cluster and updates the Secret if one exists.
 func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	return CreateOrUpdateWithContext(context.Background(), c)
+}
+
+// CreateOrUpdateWithContext is same as CreateOrUpdate but uses the
+// context passed for the calls to API Server and the hooks.
+func CreateOrUpdateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	var s *corev1.Secret
 	var err error
 	if c.GenSecretFunc != nil {
@@ -184,15 +251,30 @@ func CreateOrUpdate(c Conf) (reconcile.Result, error) {
 		maybeUpdateFunc = MaybeUpdate
 	}
 
-	result, err := operation.CreateOrUpdate(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          s,
-		ExistingObject:  &corev1.Secret{},
-		OwnerReference:  c.OwnerReference,
-		MaybeUpdateFunc: maybeUpdateFunc,
-		AfterUpdateFunc: c.AfterUpdateFunc,
-		AfterCreateFunc: c.AfterCreateFunc,
+	maybeUpdateWithDiffFunc := c.MaybeUpdateWithDiffFunc
+	if maybeUpdateWithDiffFunc == nil && c.MaybeUpdateFunc == nil {
+		maybeUpdateWithDiffFunc = MaybeUpdateWithDiff
+	}
+
+	result, err := operation.CreateOrUpdateWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Object:                     s,
+		ExistingObject:             &corev1.Secret{},
+		OwnerReference:             c.OwnerReference,
+		MaybeUpdateFunc:            maybeUpdateFunc,
+		MaybeUpdateWithDiffFunc:    maybeUpdateWithDiffFunc,
+		DiffHook:                   c.DiffHook,
+		Logger:                     c.Logger,
+		RedactPaths:                redactPaths,
+		AfterUpdateFunc:            c.AfterUpdateFunc,
+		AfterUpdateWithContextFunc: c.AfterUpdateWithContextFunc,
+		AfterCreateFunc:            c.AfterCreateFunc,
+		AfterCreateWithContextFunc: c.AfterCreateWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to create or update secret")
@@ -204,6 +286,12 @@ func CreateOrUpdate(c Conf) (reconcile.Result, error) {
 // Delete generates the ObjectMeta for Secret as per the `Conf` struct
 // passed and deletes it from the cluster
 func Delete(c Conf) (reconcile.Result, error) {
+	return DeleteWithContext(context.Background(), c)
+}
+
+// DeleteWithContext is same as Delete but uses the context passed for
+// the calls to API Server and the hooks.
+func DeleteWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	om, err := meta.GenerateObjectMeta(meta.Conf{
 		Instance:           c.Instance,
 		Name:               c.Name,
@@ -217,11 +305,17 @@ func Delete(c Conf) (reconcile.Result, error) {
 		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for secret")
 	}
 
-	result, err := operation.Delete(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          &corev1.Secret{ObjectMeta: *om},
-		AfterDeleteFunc: c.AfterDeleteFunc,
+	result, err := operation.DeleteWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Logger:                     c.Logger,
+		Object:                     &corev1.Secret{ObjectMeta: *om},
+		AfterDeleteFunc:            c.AfterDeleteFunc,
+		AfterDeleteWithContextFunc: c.AfterDeleteWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to delete secret")
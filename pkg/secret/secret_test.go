@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
 	"github.com/ankitrgadiya/operatorlib/pkg/secret"
 
 	"github.com/golang/mock/gomock"
@@ -180,6 +182,34 @@ func TestMaybeUpdate(t *testing.T) {
 	})
 }
 
+func TestMaybeUpdateWithDiff(t *testing.T) {
+	t.Run("bad objects", func(t *testing.T) {
+		diff, err := secret.MaybeUpdateWithDiff(&mocks.MockObject{}, &corev1.Secret{})
+		assert.Error(t, err)
+		assert.Empty(t, diff)
+	})
+	t.Run("up-to-date secrets", func(t *testing.T) {
+		diff, err := secret.MaybeUpdateWithDiff(
+			&corev1.Secret{Data: map[string][]byte{"key": []byte("value")}},
+			&corev1.Secret{Data: map[string][]byte{"key": []byte("value")}},
+		)
+		assert.NoError(t, err)
+		assert.Empty(t, diff)
+	})
+	t.Run("update data in secret is redacted", func(t *testing.T) {
+		existingSecret := &corev1.Secret{Data: map[string][]byte{"key": []byte("value")}}
+		newSecret := &corev1.Secret{Data: map[string][]byte{"key": []byte("new-value"), "new-key": []byte("value")}}
+
+		diff, err := secret.MaybeUpdateWithDiff(existingSecret, newSecret)
+		assert.NoError(t, err)
+		assert.Equal(t, []operation.FieldDiff{
+			{Path: "/data/key", Old: operation.Redacted, New: operation.Redacted},
+			{Path: "/data/new-key", New: operation.Redacted},
+		}, diff)
+		assert.Equal(t, existingSecret, newSecret)
+	})
+}
+
 func TestCreate(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -507,3 +537,98 @@ func TestDelete(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+type contextKey struct{}
+
+func TestWithContext(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	ctx := context.WithValue(context.Background(), contextKey{}, "test")
+	hook := func(ctx context.Context, _ interfaces.Object, _ interfaces.Reconcile) (reconcile.Result, error) {
+		if ctx.Value(contextKey{}) != "test" {
+			return reconcile.Result{}, errors.New("context is not passed")
+		}
+		return reconcile.Result{}, nil
+	}
+
+	t.Run("create or update secret", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := secret.CreateOrUpdateWithContext(ctx, secret.Conf{
+			Name:                       "test-existing-secret",
+			Namespace:                  "test-namespace",
+			Instance:                   i,
+			Reconcile:                  r,
+			Timeout:                    time.Second,
+			AfterUpdateWithContextFunc: hook,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("delete secret", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := secret.DeleteWithContext(ctx, secret.Conf{
+			Name:                       "test-existing-secret",
+			Namespace:                  "test-namespace",
+			Instance:                   i,
+			Reconcile:                  r,
+			AfterDeleteWithContextFunc: hook,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("hook fails without context", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := secret.CreateWithContext(context.Background(), secret.Conf{
+			Name:                       "test-secret",
+			Namespace:                  "test-namespace",
+			Instance:                   i,
+			Reconcile:                  r,
+			AfterCreateWithContextFunc: hook,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	i, r := mockSetup(controller)
+	client := r.GetClient()
+	plan := &operation.Plan{}
+
+	_, err := secret.Create(secret.Conf{
+		Name:      "test-secret",
+		Namespace: "test-namespace",
+		Instance:  i,
+		Reconcile: r,
+		DryRun:    operation.ServerDryRun,
+		Plan:      plan,
+	})
+	assert.NoError(t, err)
+
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "test-secret", Namespace: "test-namespace"}, &corev1.Secret{})
+	assert.Error(t, err)
+
+	assert.Len(t, plan.Entries, 1)
+	assert.Equal(t, operation.ActionCreate, plan.Entries[0].Action)
+	assert.Equal(t, "Secret", plan.Entries[0].Kind)
+}
+
+func TestEvents(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	i, mr := mockSetup(controller)
+	r := mocks.NewRecorderReconcile(mr, 10)
+	reasons := operation.EventReasons{Created: "SecretCreated", Deleted: "SecretDeleted"}
+
+	_, err := secret.Create(secret.Conf{Instance: i, Reconcile: r, Name: "test-secret", Namespace: "test-namespace", EventReasons: reasons})
+	assert.NoError(t, err)
+	_, err = secret.Delete(secret.Conf{Instance: i, Reconcile: r, Name: "test-existing-secret", Namespace: "test-namespace", EventReasons: reasons})
+	assert.NoError(t, err)
+
+	if assert.Len(t, r.Recorder.Events, 2) {
+		assert.Equal(t, "Normal SecretCreated Created Secret test-secret", <-r.Recorder.Events)
+		assert.Equal(t, "Normal SecretDeleted Deleted Secret test-existing-secret", <-r.Recorder.Events)
+	}
+}
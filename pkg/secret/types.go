@@ -1,10 +1,13 @@
 package secret
 
 import (
+	"time"
+
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
 	"github.com/ankitrgadiya/operatorlib/pkg/meta"
 	"github.com/ankitrgadiya/operatorlib/pkg/operation"
 
+	"github.com/go-logr/logr"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -63,4 +66,36 @@ type Conf struct {
 	GenStringDataFunc
 	// Type defines the type of Secret
 	Type string
+	// Timeout limits the duration of every call made to the API
+	// Server while performing operations on Secret
+	Timeout time.Duration
+	// AfterCreateWithContextFunc hook is called after creating the
+	// Secret. If set, it is called instead of AfterCreateFunc.
+	operation.AfterCreateWithContextFunc
+	// AfterUpdateWithContextFunc hook is called after updating the
+	// Secret. If set, it is called instead of AfterUpdateFunc.
+	operation.AfterUpdateWithContextFunc
+	// AfterDeleteWithContextFunc hook is called after deleting the
+	// Secret. If set, it is called instead of AfterDeleteFunc.
+	operation.AfterDeleteWithContextFunc
+	// DryRun makes the operations skip the changes to the Secret, see
+	// operation.DryRunMode for the modes
+	DryRun operation.DryRunMode
+	// Plan, if set, gets the entry for the change made, or to be made
+	// in dry-run mode, to the Secret
+	Plan *operation.Plan
+	// MaybeUpdateWithDiffFunc defines an update function with custom
+	// logic for Secret update which also reports the fields
+	// changed. MaybeUpdateWithDiff is used by default unless
+	// MaybeUpdateFunc is set
+	operation.MaybeUpdateWithDiffFunc
+	// DiffHook is called with the fields changed after updating the
+	// Secret
+	DiffHook operation.DiffHookFunc
+	// Logger, if set, is used to log the operations on the Secret
+	// instead of the logger from the context or the Reconcile struct
+	Logger logr.Logger
+	// EventReasons overrides the reasons of the Events recorded for
+	// the operations on the Secret
+	EventReasons operation.EventReasons
 }
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"reflect"
 
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
@@ -117,9 +118,38 @@ func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error
 	return true, nil
 }
 
+// MaybeUpdateWithDiff implements MaybeUpdateWithDiffFunc for Service
+// object. It updates the first Service like MaybeUpdate and returns the
+// fields changed.
+func MaybeUpdateWithDiff(original interfaces.Object, new interfaces.Object) ([]operation.FieldDiff, error) {
+	o, ok := original.(*corev1.Service)
+	if !ok {
+		return nil, errors.New("failed to assert the original object")
+	}
+	before := o.DeepCopy()
+
+	update, err := MaybeUpdate(original, new)
+	if err != nil || !update {
+		return nil, err
+	}
+
+	diff, err := operation.Diff(before, original)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute the diff of service")
+	}
+
+	return diff, nil
+}
+
 // Create generates the Service as per the `Conf` struct passed and
 // creates it in the cluster
 func Create(c Conf) (reconcile.Result, error) {
+	return CreateWithContext(context.Background(), c)
+}
+
+// CreateWithContext is same as Create but uses the context passed for
+// the calls to API Server and the hooks.
+func CreateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	var s *corev1.Service
 	var err error
 	if c.GenServiceFunc != nil {
@@ -131,12 +161,18 @@ func Create(c Conf) (reconcile.Result, error) {
 		return reconcile.Result{}, errors.Wrap(err, "failed to generate service")
 	}
 
-	result, err := operation.Create(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          s,
-		OwnerReference:  c.OwnerReference,
-		AfterCreateFunc: c.AfterCreateFunc,
+	result, err := operation.CreateWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Logger:                     c.Logger,
+		Object:                     s,
+		OwnerReference:             c.OwnerReference,
+		AfterCreateFunc:            c.AfterCreateFunc,
+		AfterCreateWithContextFunc: c.AfterCreateWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to create configmap")
@@ -144,8 +180,16 @@ func Create(c Conf) (reconcile.Result, error) {
 	return result, nil
 }
 
-// Update generates the Service as per the `Conf` struct passed and compares it with the in-cluster version. If required, it updates the in-cluster Service with the changes.
+// Update generates the Service as per the `Conf` struct passed and
+// compares it with the in-cluster version. If required, it updates
+// the in-cluster Service with the changes.
 func Update(c Conf) (reconcile.Result, error) {
+	return UpdateWithContext(context.Background(), c)
+}
+
+// UpdateWithContext is same as Update but uses the context passed for
+// the calls to API Server and the hooks.
+func UpdateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	var s *corev1.Service
 	var err error
 	if c.GenServiceFunc != nil {
@@ -164,13 +208,26 @@ func Update(c Conf) (reconcile.Result, error) {
 		maybeUpdateFunc = MaybeUpdate
 	}
 
-	result, err := operation.Update(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          s,
-		ExistingObject:  &corev1.Service{},
-		MaybeUpdateFunc: maybeUpdateFunc,
-		AfterUpdateFunc: c.AfterUpdateFunc,
+	maybeUpdateWithDiffFunc := c.MaybeUpdateWithDiffFunc
+	if maybeUpdateWithDiffFunc == nil && c.MaybeUpdateFunc == nil {
+		maybeUpdateWithDiffFunc = MaybeUpdateWithDiff
+	}
+
+	result, err := operation.UpdateWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Object:                     s,
+		ExistingObject:             &corev1.Service{},
+		MaybeUpdateFunc:            maybeUpdateFunc,
+		MaybeUpdateWithDiffFunc:    maybeUpdateWithDiffFunc,
+		DiffHook:                   c.DiffHook,
+		Logger:                     c.Logger,
+		AfterUpdateFunc:            c.AfterUpdateFunc,
+		AfterUpdateWithContextFunc: c.AfterUpdateWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to update service")
@@ -183,6 +240,12 @@ func Update(c Conf) (reconcile.Result, error) {
 // functions. It creates the Service object if it is not already in
 // the cluster and updates the Service if one exists.
 func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	return CreateOrUpdateWithContext(context.Background(), c)
+}
+
+// CreateOrUpdateWithContext is same as CreateOrUpdate but uses the
+// context passed for the calls to API Server and the hooks.
+func CreateOrUpdateWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	var s *corev1.Service
 	var err error
 	if c.GenServiceFunc != nil {
@@ -201,15 +264,29 @@ func CreateOrUpdate(c Conf) (reconcile.Result, error) {
 		maybeUpdateFunc = MaybeUpdate
 	}
 
-	result, err := operation.CreateOrUpdate(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          s,
-		ExistingObject:  &corev1.Service{},
-		OwnerReference:  c.OwnerReference,
-		MaybeUpdateFunc: maybeUpdateFunc,
-		AfterUpdateFunc: c.AfterUpdateFunc,
-		AfterCreateFunc: c.AfterCreateFunc,
+	maybeUpdateWithDiffFunc := c.MaybeUpdateWithDiffFunc
+	if maybeUpdateWithDiffFunc == nil && c.MaybeUpdateFunc == nil {
+		maybeUpdateWithDiffFunc = MaybeUpdateWithDiff
+	}
+
+	result, err := operation.CreateOrUpdateWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Object:                     s,
+		ExistingObject:             &corev1.Service{},
+		OwnerReference:             c.OwnerReference,
+		MaybeUpdateFunc:            maybeUpdateFunc,
+		MaybeUpdateWithDiffFunc:    maybeUpdateWithDiffFunc,
+		DiffHook:                   c.DiffHook,
+		Logger:                     c.Logger,
+		AfterUpdateFunc:            c.AfterUpdateFunc,
+		AfterUpdateWithContextFunc: c.AfterUpdateWithContextFunc,
+		AfterCreateFunc:            c.AfterCreateFunc,
+		AfterCreateWithContextFunc: c.AfterCreateWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to create or update service")
@@ -221,6 +298,12 @@ func CreateOrUpdate(c Conf) (reconcile.Result, error) {
 // Delete generates the ObjectMeta for Service as per the `Conf`
 // struct passed and deletes it from the cluster
 func Delete(c Conf) (reconcile.Result, error) {
+	return DeleteWithContext(context.Background(), c)
+}
+
+// DeleteWithContext is same as Delete but uses the context passed for
+// the calls to API Server and the hooks.
+func DeleteWithContext(ctx context.Context, c Conf) (reconcile.Result, error) {
 	om, err := meta.GenerateObjectMeta(meta.Conf{
 		Instance:           c.Instance,
 		Name:               c.Name,
@@ -233,11 +316,17 @@ func Delete(c Conf) (reconcile.Result, error) {
 		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for service")
 	}
 
-	result, err := operation.Delete(operation.Conf{
-		Instance:        c.Instance,
-		Reconcile:       c.Reconcile,
-		Object:          &corev1.Service{ObjectMeta: *om},
-		AfterDeleteFunc: c.AfterDeleteFunc,
+	result, err := operation.DeleteWithContext(ctx, operation.Conf{
+		Instance:                   c.Instance,
+		Reconcile:                  c.Reconcile,
+		Timeout:                    c.Timeout,
+		DryRun:                     c.DryRun,
+		Plan:                       c.Plan,
+		EventReasons:               c.EventReasons,
+		Logger:                     c.Logger,
+		Object:                     &corev1.Service{ObjectMeta: *om},
+		AfterDeleteFunc:            c.AfterDeleteFunc,
+		AfterDeleteWithContextFunc: c.AfterDeleteWithContextFunc,
 	})
 	if err != nil {
 		return result, errors.Wrap(err, "failed to delete service")
@@ -1,11 +1,14 @@
 package service_test
 
 import (
+	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
 	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
 	"github.com/ankitrgadiya/operatorlib/pkg/service"
 
 	"github.com/golang/mock/gomock"
@@ -225,6 +228,30 @@ func TestMaybeUpdate(t *testing.T) {
 	})
 }
 
+func TestMaybeUpdateWithDiff(t *testing.T) {
+	t.Run("bad objects", func(t *testing.T) {
+		diff, err := service.MaybeUpdateWithDiff(&mocks.MockObject{}, &corev1.Service{})
+		assert.Error(t, err)
+		assert.Empty(t, diff)
+	})
+	t.Run("different types", func(t *testing.T) {
+		diff, err := service.MaybeUpdateWithDiff(
+			&corev1.Service{Spec: corev1.ServiceSpec{Type: "ClusterIP"}},
+			&corev1.Service{Spec: corev1.ServiceSpec{Type: "NodePort"}},
+		)
+		assert.Error(t, err)
+		assert.Empty(t, diff)
+	})
+	t.Run("update selector", func(t *testing.T) {
+		existingService := &corev1.Service{Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "old"}}}
+		newService := &corev1.Service{Spec: corev1.ServiceSpec{Selector: map[string]string{"app": "new"}}}
+
+		diff, err := service.MaybeUpdateWithDiff(existingService, newService)
+		assert.NoError(t, err)
+		assert.Equal(t, []operation.FieldDiff{{Path: "/spec/selector/app", Old: "old", New: "new"}}, diff)
+	})
+}
+
 func TestCreate(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -406,3 +433,99 @@ func TestDelete(t *testing.T) {
 		assert.NoError(t, err)
 	})
 }
+
+type contextKey struct{}
+
+func TestWithContext(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	ctx := context.WithValue(context.Background(), contextKey{}, "test")
+	hook := func(ctx context.Context, _ interfaces.Object, _ interfaces.Reconcile) (reconcile.Result, error) {
+		if ctx.Value(contextKey{}) != "test" {
+			return reconcile.Result{}, errors.New("context is not passed")
+		}
+		return reconcile.Result{}, nil
+	}
+
+	t.Run("create or update service", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := service.CreateOrUpdateWithContext(ctx, service.Conf{
+			Name:                       "test-existing-service",
+			Namespace:                  "test",
+			Instance:                   i,
+			Reconcile:                  r,
+			Type:                       "ClusterIP",
+			Timeout:                    time.Second,
+			AfterUpdateWithContextFunc: hook,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("delete service", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := service.DeleteWithContext(ctx, service.Conf{
+			Name:                       "test-existing-service",
+			Namespace:                  "test",
+			Instance:                   i,
+			Reconcile:                  r,
+			AfterDeleteWithContextFunc: hook,
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("hook fails without context", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := service.CreateWithContext(context.Background(), service.Conf{
+			Name:                       "test-service",
+			Namespace:                  "test",
+			Instance:                   i,
+			Reconcile:                  r,
+			AfterCreateWithContextFunc: hook,
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	i, r := mockSetup(controller)
+	client := r.GetClient()
+	plan := &operation.Plan{}
+
+	_, err := service.Delete(service.Conf{
+		Name:      "test-existing-service",
+		Namespace: "test",
+		Instance:  i,
+		Reconcile: r,
+		DryRun:    operation.ClientDryRun,
+		Plan:      plan,
+	})
+	assert.NoError(t, err)
+
+	err = client.Get(context.TODO(), types.NamespacedName{Name: "test-existing-service", Namespace: "test"}, &corev1.Service{})
+	assert.NoError(t, err)
+
+	assert.Len(t, plan.Entries, 1)
+	assert.Equal(t, operation.ActionDelete, plan.Entries[0].Action)
+	assert.Equal(t, "Service", plan.Entries[0].Kind)
+}
+
+func TestEvents(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	i, mr := mockSetup(controller)
+	r := mocks.NewRecorderReconcile(mr, 10)
+	reasons := operation.EventReasons{Created: "ServiceCreated", Deleted: "ServiceDeleted"}
+
+	_, err := service.Create(service.Conf{Instance: i, Reconcile: r, Name: "test-service", Namespace: "test", EventReasons: reasons})
+	assert.NoError(t, err)
+	_, err = service.Delete(service.Conf{Instance: i, Reconcile: r, Name: "test-existing-service", Namespace: "test", EventReasons: reasons})
+	assert.NoError(t, err)
+
+	if assert.Len(t, r.Recorder.Events, 2) {
+		assert.Equal(t, "Normal ServiceCreated Created Service test-service", <-r.Recorder.Events)
+		assert.Equal(t, "Normal ServiceDeleted Deleted Service test-existing-service", <-r.Recorder.Events)
+	}
+}
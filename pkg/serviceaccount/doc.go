@@ -0,0 +1,3 @@
+// Package serviceaccount provides functions for manipulating
+// ServiceAccount object in Kubernetes cluster.
+package serviceaccount
@@ -0,0 +1,38 @@
+package serviceaccount_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/serviceaccount"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	result, err := serviceaccount.CreateOrUpdate(serviceaccount.Conf{
+		// Instance is the pointer to owner object under which
+		// ServiceAccount is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the ServiceAccount object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated ServiceAccount.
+		Name: "sa-test",
+		// GenImagePullSecretsFunc is the function that generates the
+		// Secrets used to pull images of the Pods.
+		GenImagePullSecretsFunc: func(interfaces.Object) ([]corev1.LocalObjectReference, error) {
+			return []corev1.LocalObjectReference{{Name: "registry-credentials"}}, nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
@@ -0,0 +1,217 @@
+package serviceaccount
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateServiceAccount generates ServiceAccount object as per the
+// `Conf` struct passed.
+func GenerateServiceAccount(c Conf) (sa *corev1.ServiceAccount, err error) {
+	var om *metav1.ObjectMeta
+	var imagePullSecrets []corev1.LocalObjectReference
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenImagePullSecretsFunc != nil {
+		imagePullSecrets, err = c.GenImagePullSecretsFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate image pull secrets")
+		}
+	}
+
+	sa = &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: "v1",
+		},
+		ObjectMeta:                   *om,
+		ImagePullSecrets:             imagePullSecrets,
+		AutomountServiceAccountToken: c.AutomountServiceAccountToken,
+	}
+
+	return sa, nil
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for ServiceAccount object. It
+// compares the two ServiceAccounts being passed and update the first
+// one if required. Secrets of the ServiceAccount are populated by the
+// token controller, so those are never compared. Automount of the API
+// token is only compared if set.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	osa, ok := original.(*corev1.ServiceAccount)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	nsa, ok := new.(*corev1.ServiceAccount)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	update := false
+
+	if !equality.Semantic.DeepEqual(osa.ImagePullSecrets, nsa.ImagePullSecrets) {
+		osa.ImagePullSecrets = nsa.ImagePullSecrets
+		update = true
+	}
+
+	if nsa.AutomountServiceAccountToken != nil && !equality.Semantic.DeepEqual(osa.AutomountServiceAccountToken, nsa.AutomountServiceAccountToken) {
+		osa.AutomountServiceAccountToken = nsa.AutomountServiceAccountToken
+		update = true
+	}
+
+	return update, nil
+}
+
+// Create generates the ServiceAccount as per the `Conf` struct passed
+// and creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var sa *corev1.ServiceAccount
+	var err error
+	if c.GenServiceAccountFunc != nil {
+		sa, err = c.GenServiceAccountFunc(c)
+	} else {
+		sa, err = GenerateServiceAccount(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate serviceaccount")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          sa,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create serviceaccount")
+	}
+
+	return result, nil
+}
+
+// Update generates the ServiceAccount as per the `Conf` struct passed
+// and compares it with the in-cluster version. If required, it
+// updates the in-cluster ServiceAccount with the changes. For
+// comparing the ServiceAccounts, it uses `MaybeUpdate` function by
+// default but can also use `MaybeUpdateFunc` from `Conf` if passed.
+func Update(c Conf) (reconcile.Result, error) {
+	var sa *corev1.ServiceAccount
+	var err error
+	if c.GenServiceAccountFunc != nil {
+		sa, err = c.GenServiceAccountFunc(c)
+	} else {
+		sa, err = GenerateServiceAccount(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate serviceaccount")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          sa,
+		ExistingObject:  &corev1.ServiceAccount{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update serviceaccount")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update` functions.
+// It creates the ServiceAccount object if it is not already in the
+// cluster and updates the ServiceAccount if one exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var sa *corev1.ServiceAccount
+	var err error
+	if c.GenServiceAccountFunc != nil {
+		sa, err = c.GenServiceAccountFunc(c)
+	} else {
+		sa, err = GenerateServiceAccount(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate serviceaccount")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          sa,
+		ExistingObject:  &corev1.ServiceAccount{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update serviceaccount")
+	}
+
+	return result, nil
+}
+
+// Delete generates the ObjectMeta for ServiceAccount as per the `Conf`
+// struct passed and deletes it from the cluster
+func Delete(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for serviceaccount")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &corev1.ServiceAccount{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete serviceaccount")
+	}
+
+	return result, nil
+}
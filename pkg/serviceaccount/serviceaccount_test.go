@@ -0,0 +1,295 @@
+package serviceaccount_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/serviceaccount"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func boolPtr(b bool) *bool { return &b }
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-existing-serviceaccount", Namespace: "test"},
+		Secrets:    []corev1.ObjectReference{{Name: "test-existing-serviceaccount-token-abcde"}},
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{sa}...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+func TestGenerateServiceAccount(t *testing.T) {
+	t.Run("generate empty serviceaccount", func(t *testing.T) {
+		expected := &corev1.ServiceAccount{TypeMeta: metav1.TypeMeta{
+			Kind:       "ServiceAccount",
+			APIVersion: "v1",
+		}}
+
+		result, err := serviceaccount.GenerateServiceAccount(serviceaccount.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := serviceaccount.GenerateServiceAccount(serviceaccount.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate image pull secrets", func(t *testing.T) {
+		result, err := serviceaccount.GenerateServiceAccount(serviceaccount.Conf{
+			GenImagePullSecretsFunc: func(interfaces.Object) ([]corev1.LocalObjectReference, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate serviceaccount", func(t *testing.T) {
+		expected := &corev1.ServiceAccount{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "ServiceAccount",
+				APIVersion: "v1",
+			},
+			ImagePullSecrets:             []corev1.LocalObjectReference{{Name: "registry"}},
+			AutomountServiceAccountToken: boolPtr(false),
+		}
+
+		result, err := serviceaccount.GenerateServiceAccount(serviceaccount.Conf{
+			GenImagePullSecretsFunc: func(interfaces.Object) ([]corev1.LocalObjectReference, error) {
+				return []corev1.LocalObjectReference{{Name: "registry"}}, nil
+			},
+			AutomountServiceAccountToken: boolPtr(false),
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := serviceaccount.MaybeUpdate(&mocks.MockObject{}, &corev1.ServiceAccount{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := serviceaccount.MaybeUpdate(&corev1.ServiceAccount{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := serviceaccount.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare serviceaccounts", func(t *testing.T) {
+		t.Run("empty serviceaccounts", func(t *testing.T) {
+			result, err := serviceaccount.MaybeUpdate(&corev1.ServiceAccount{}, &corev1.ServiceAccount{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("token secrets are ignored", func(t *testing.T) {
+			existingServiceAccount := &corev1.ServiceAccount{
+				Secrets:                      []corev1.ObjectReference{{Name: "test-token-abcde"}},
+				AutomountServiceAccountToken: boolPtr(true),
+			}
+
+			result, err := serviceaccount.MaybeUpdate(existingServiceAccount, &corev1.ServiceAccount{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different image pull secrets", func(t *testing.T) {
+			existingServiceAccount := &corev1.ServiceAccount{
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+			}
+			newServiceAccount := &corev1.ServiceAccount{
+				ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}, {Name: "mirror"}},
+			}
+
+			result, err := serviceaccount.MaybeUpdate(existingServiceAccount, newServiceAccount)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingServiceAccount, newServiceAccount)
+		})
+		t.Run("different automount", func(t *testing.T) {
+			existingServiceAccount := &corev1.ServiceAccount{AutomountServiceAccountToken: boolPtr(true)}
+			newServiceAccount := &corev1.ServiceAccount{AutomountServiceAccountToken: boolPtr(false)}
+
+			result, err := serviceaccount.MaybeUpdate(existingServiceAccount, newServiceAccount)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingServiceAccount, newServiceAccount)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := serviceaccount.Create(serviceaccount.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := serviceaccount.Create(serviceaccount.Conf{GenServiceAccountFunc: func(serviceaccount.Conf) (*corev1.ServiceAccount, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := serviceaccount.Create(serviceaccount.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create serviceaccount", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := serviceaccount.Create(serviceaccount.Conf{
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := serviceaccount.Update(serviceaccount.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := serviceaccount.Update(serviceaccount.Conf{GenServiceAccountFunc: func(serviceaccount.Conf) (*corev1.ServiceAccount, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := serviceaccount.Update(serviceaccount.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("update serviceaccount", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := serviceaccount.Update(serviceaccount.Conf{
+			Name:                         "test-existing-serviceaccount",
+			Namespace:                    "test",
+			Instance:                     i,
+			Reconcile:                    r,
+			AutomountServiceAccountToken: boolPtr(false),
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := serviceaccount.CreateOrUpdate(serviceaccount.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := serviceaccount.CreateOrUpdate(serviceaccount.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update serviceaccount", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := serviceaccount.CreateOrUpdate(serviceaccount.Conf{
+			Name:      "test-existing-serviceaccount",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := serviceaccount.Delete(serviceaccount.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to delete", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := serviceaccount.Delete(serviceaccount.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("delete serviceaccount", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := serviceaccount.Delete(serviceaccount.Conf{
+			Name:      "test-existing-serviceaccount",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
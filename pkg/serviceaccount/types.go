@@ -0,0 +1,69 @@
+package serviceaccount
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// GenServiceAccountFunc defines a function which generates
+// ServiceAccount
+type GenServiceAccountFunc func(Conf) (*corev1.ServiceAccount, error)
+
+// GenImagePullSecretsFunc defines a function which generates
+// references to the Secrets used for pulling images of the Pods
+// running as the ServiceAccount
+type GenImagePullSecretsFunc func(interfaces.Object) ([]corev1.LocalObjectReference, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on ServiceAccount objects.
+type Conf struct {
+	// Instance is the Owner object which manages the ServiceAccount
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the ServiceAccount
+	Name string
+	// Namespace of the ServiceAccount
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on ServiceAccount before creating it in cluster
+	OwnerReference bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for ServiceAccount update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the
+	// ServiceAccount
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the
+	// ServiceAccount
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the
+	// ServiceAccount
+	operation.AfterDeleteFunc
+	// GenServiceAccountFunc defines a function to generate the
+	// ServiceAccount object. The package comes with default
+	// generator function which is used by operation functions. By
+	// specifying this field, user can override the default function
+	// with a custom one.
+	GenServiceAccountFunc
+	// GenImagePullSecretsFunc defines a function to generate image
+	// pull secrets for the ServiceAccount
+	GenImagePullSecretsFunc
+	// AutomountServiceAccountToken can be used to opt out of
+	// mounting the API token in the Pods running as the
+	// ServiceAccount. If nil, the token is mounted.
+	AutomountServiceAccountToken *bool
+}
@@ -0,0 +1,3 @@
+// Package statefulset provides functions for manipulating StatefulSet
+// object in Kubernetes cluster.
+package statefulset
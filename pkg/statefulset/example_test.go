@@ -0,0 +1,52 @@
+package statefulset_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+	"github.com/ankitrgadiya/operatorlib/pkg/statefulset"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	result, err := statefulset.CreateOrUpdate(statefulset.Conf{
+		// Instance is the pointer to owner object under which
+		// StatefulSet is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the StatefulSet object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// Name is the name of generated StatefulSet.
+		Name: "sts-test",
+		// ServiceName is the governing Service of the StatefulSet.
+		// This field is immutable.
+		ServiceName: "sts-test",
+		// GenSelectorFunc is the function that generates the label
+		// selector for the StatefulSet. This field is immutable.
+		GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, nil
+		},
+		// GenContainersFunc is the function that generates the
+		// containers for the Pod template.
+		GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) {
+			return []corev1.Container{{Name: "redis", Image: "redis:5"}}, nil
+		},
+	})
+	if operation.IsImmutableFieldError(err) {
+		// StatefulSet needs to be deleted and created again for the
+		// changes to take effect.
+		log.Print(err)
+	} else if err != nil {
+		log.Fatal(result, err)
+	}
+}
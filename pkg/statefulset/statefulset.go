@@ -0,0 +1,326 @@
+package statefulset
+
+import (
+	"reflect"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// GenerateStatefulSet generates StatefulSet object as per the `Conf`
+// struct passed. If the generated Pod template does not have any
+// labels then the MatchLabels of the generated selector are used as
+// labels for the Pod template.
+func GenerateStatefulSet(c Conf) (s *appsv1.StatefulSet, err error) {
+	var om *metav1.ObjectMeta
+	var template corev1.PodTemplateSpec
+	var replicas *int32
+	var selector *metav1.LabelSelector
+	var volumeClaimTemplates []corev1.PersistentVolumeClaim
+	var updateStrategy appsv1.StatefulSetUpdateStrategy
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenPodTemplateSpecFunc != nil {
+		var t *corev1.PodTemplateSpec
+		t, err = c.GenPodTemplateSpecFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate pod template")
+		}
+		if t != nil {
+			template = *t
+		}
+	}
+
+	if c.GenContainersFunc != nil {
+		template.Spec.Containers, err = c.GenContainersFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate containers")
+		}
+	}
+
+	if c.GenReplicasFunc != nil {
+		replicas, err = c.GenReplicasFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate replicas")
+		}
+	}
+
+	if c.GenSelectorFunc != nil {
+		selector, err = c.GenSelectorFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate selector")
+		}
+	}
+
+	if c.GenVolumeClaimTemplatesFunc != nil {
+		volumeClaimTemplates, err = c.GenVolumeClaimTemplatesFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate volume claim templates")
+		}
+	}
+
+	if c.GenUpdateStrategyFunc != nil {
+		updateStrategy, err = c.GenUpdateStrategyFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate update strategy")
+		}
+	}
+
+	if selector != nil && template.Labels == nil && selector.MatchLabels != nil {
+		template.Labels = make(map[string]string, len(selector.MatchLabels))
+		for key, value := range selector.MatchLabels {
+			template.Labels[key] = value
+		}
+	}
+
+	s = &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		},
+		ObjectMeta: *om,
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:             replicas,
+			Selector:             selector,
+			Template:             template,
+			VolumeClaimTemplates: volumeClaimTemplates,
+			ServiceName:          c.ServiceName,
+			PodManagementPolicy:  appsv1.PodManagementPolicyType(c.PodManagementPolicy),
+			UpdateStrategy:       updateStrategy,
+		},
+	}
+
+	return s, nil
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for StatefulSet object. It
+// compares the two StatefulSets being passed and update the first one
+// if required. Selector, ServiceName, VolumeClaimTemplates and
+// PodManagementPolicy are immutable fields of StatefulSet so it
+// returns operation.ImmutableFieldError if any of those is
+// different. Like the Deployment, only the fields set in the
+// generated StatefulSet are compared for the Pod template, the volume
+// claim templates and the update strategy since API Server fills in
+// the defaults for the rest. Replicas are only compared if set.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	os, ok := original.(*appsv1.StatefulSet)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	ns, ok := new.(*appsv1.StatefulSet)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	if !equality.Semantic.DeepEqual(os.Spec.Selector, ns.Spec.Selector) {
+		return false, operation.NewImmutableFieldError("StatefulSet", "spec.selector")
+	}
+
+	if os.Spec.ServiceName != ns.Spec.ServiceName {
+		return false, operation.NewImmutableFieldError("StatefulSet", "spec.serviceName")
+	}
+
+	// PodManagementPolicy is defaulted by the API Server, so it is
+	// only compared if set in the generated StatefulSet.
+	if ns.Spec.PodManagementPolicy != "" && os.Spec.PodManagementPolicy != ns.Spec.PodManagementPolicy {
+		return false, operation.NewImmutableFieldError("StatefulSet", "spec.podManagementPolicy")
+	}
+
+	if !equalVolumeClaimTemplates(os.Spec.VolumeClaimTemplates, ns.Spec.VolumeClaimTemplates) {
+		return false, operation.NewImmutableFieldError("StatefulSet", "spec.volumeClaimTemplates")
+	}
+
+	update := false
+
+	if ns.Spec.Replicas != nil && !reflect.DeepEqual(os.Spec.Replicas, ns.Spec.Replicas) {
+		os.Spec.Replicas = ns.Spec.Replicas
+		update = true
+	}
+
+	if !equality.Semantic.DeepDerivative(ns.Spec.UpdateStrategy, os.Spec.UpdateStrategy) {
+		os.Spec.UpdateStrategy = ns.Spec.UpdateStrategy
+		update = true
+	}
+
+	if !operation.DeepDerivative(ns.Spec.Template, os.Spec.Template) {
+		os.Spec.Template = ns.Spec.Template
+		update = true
+	}
+
+	return update, nil
+}
+
+// equalVolumeClaimTemplates compares the volume claim templates in
+// cluster with the generated ones. Only name, labels, annotations and
+// fields set in the spec of the generated templates are compared.
+func equalVolumeClaimTemplates(original, new []corev1.PersistentVolumeClaim) bool {
+	if len(original) != len(new) {
+		return false
+	}
+
+	for i := 0; i < len(original); i++ {
+		if original[i].Name != new[i].Name ||
+			!equality.Semantic.DeepDerivative(new[i].Labels, original[i].Labels) ||
+			!equality.Semantic.DeepDerivative(new[i].Annotations, original[i].Annotations) ||
+			!equality.Semantic.DeepDerivative(new[i].Spec, original[i].Spec) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Create generates the StatefulSet as per the `Conf` struct passed
+// and creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var s *appsv1.StatefulSet
+	var err error
+	if c.GenStatefulSetFunc != nil {
+		s, err = c.GenStatefulSetFunc(c)
+	} else {
+		s, err = GenerateStatefulSet(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate statefulset")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          s,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create statefulset")
+	}
+
+	return result, nil
+}
+
+// Update generates the StatefulSet as per the `Conf` struct passed
+// and compares it with the in-cluster version. If required, it
+// updates the in-cluster StatefulSet with the changes. For comparing
+// the StatefulSets, it uses `MaybeUpdate` function by default but can
+// also use `MaybeUpdateFunc` from `Conf` if passed.
+func Update(c Conf) (reconcile.Result, error) {
+	var s *appsv1.StatefulSet
+	var err error
+	if c.GenStatefulSetFunc != nil {
+		s, err = c.GenStatefulSetFunc(c)
+	} else {
+		s, err = GenerateStatefulSet(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate statefulset")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          s,
+		ExistingObject:  &appsv1.StatefulSet{},
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update statefulset")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update`
+// functions. It creates the StatefulSet object if it is not already
+// in the cluster and updates the StatefulSet if one exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var s *appsv1.StatefulSet
+	var err error
+	if c.GenStatefulSetFunc != nil {
+		s, err = c.GenStatefulSetFunc(c)
+	} else {
+		s, err = GenerateStatefulSet(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate statefulset")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          s,
+		ExistingObject:  &appsv1.StatefulSet{},
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update statefulset")
+	}
+
+	return result, nil
+}
+
+// Delete generates the ObjectMeta for StatefulSet as per the `Conf`
+// struct passed and deletes it from the cluster
+func Delete(c Conf) (reconcile.Result, error) {
+	om, err := meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate objectmeta for statefulset")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          &appsv1.StatefulSet{ObjectMeta: *om},
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete statefulset")
+	}
+
+	return result, nil
+}
@@ -0,0 +1,519 @@
+package statefulset_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+	"github.com/ankitrgadiya/operatorlib/pkg/statefulset"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func int32Ptr(i int32) *int32 { return &i }
+
+func testSelector(interfaces.Object) (*metav1.LabelSelector, error) {
+	return &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}, nil
+}
+
+func testClaim(name string, size string) corev1.PersistentVolumeClaim {
+	return corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+			},
+		},
+	}
+}
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+
+	s := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-existing-statefulset", Namespace: "test"},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    int32Ptr(1),
+			ServiceName: "test",
+			Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v1"}},
+				},
+			},
+			PodManagementPolicy: appsv1.OrderedReadyPodManagement,
+		},
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{s}...)
+	sc := scheme.Scheme
+	sc.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(sc).AnyTimes()
+
+	return i, r
+}
+
+func TestGenerateStatefulSet(t *testing.T) {
+	t.Run("generate empty statefulset", func(t *testing.T) {
+		expected := &appsv1.StatefulSet{TypeMeta: metav1.TypeMeta{
+			Kind:       "StatefulSet",
+			APIVersion: "apps/v1",
+		}}
+
+		result, err := statefulset.GenerateStatefulSet(statefulset.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, expected, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := statefulset.GenerateStatefulSet(statefulset.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate pod template", func(t *testing.T) {
+		result, err := statefulset.GenerateStatefulSet(statefulset.Conf{
+			GenPodTemplateSpecFunc: func(interfaces.Object) (*corev1.PodTemplateSpec, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate containers", func(t *testing.T) {
+		result, err := statefulset.GenerateStatefulSet(statefulset.Conf{
+			GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate replicas", func(t *testing.T) {
+		result, err := statefulset.GenerateStatefulSet(statefulset.Conf{
+			GenReplicasFunc: func(interfaces.Object) (*int32, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate selector", func(t *testing.T) {
+		result, err := statefulset.GenerateStatefulSet(statefulset.Conf{
+			GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate volume claim templates", func(t *testing.T) {
+		result, err := statefulset.GenerateStatefulSet(statefulset.Conf{
+			GenVolumeClaimTemplatesFunc: func(interfaces.Object) ([]corev1.PersistentVolumeClaim, error) {
+				return nil, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate update strategy", func(t *testing.T) {
+		result, err := statefulset.GenerateStatefulSet(statefulset.Conf{
+			GenUpdateStrategyFunc: func(interfaces.Object) (appsv1.StatefulSetUpdateStrategy, error) {
+				return appsv1.StatefulSetUpdateStrategy{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate statefulset", func(t *testing.T) {
+		expected := &appsv1.StatefulSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind:       "StatefulSet",
+				APIVersion: "apps/v1",
+			},
+			Spec: appsv1.StatefulSetSpec{
+				Replicas:    int32Ptr(3),
+				ServiceName: "test",
+				Selector:    &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test"}},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "test", Image: "test:v1"}},
+					},
+				},
+				VolumeClaimTemplates: []corev1.PersistentVolumeClaim{testClaim("data", "1Gi")},
+				PodManagementPolicy:  appsv1.ParallelPodManagement,
+				UpdateStrategy:       appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+			},
+		}
+
+		result, err := statefulset.GenerateStatefulSet(statefulset.Conf{
+			GenContainersFunc: func(interfaces.Object) ([]corev1.Container, error) {
+				return []corev1.Container{{Name: "test", Image: "test:v1"}}, nil
+			},
+			GenReplicasFunc: func(interfaces.Object) (*int32, error) { return int32Ptr(3), nil },
+			GenSelectorFunc: testSelector,
+			GenVolumeClaimTemplatesFunc: func(interfaces.Object) ([]corev1.PersistentVolumeClaim, error) {
+				return []corev1.PersistentVolumeClaim{testClaim("data", "1Gi")}, nil
+			},
+			GenUpdateStrategyFunc: func(interfaces.Object) (appsv1.StatefulSetUpdateStrategy, error) {
+				return appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}, nil
+			},
+			ServiceName:         "test",
+			PodManagementPolicy: "Parallel",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := statefulset.MaybeUpdate(&mocks.MockObject{}, &appsv1.StatefulSet{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := statefulset.MaybeUpdate(&appsv1.StatefulSet{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := statefulset.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("immutable fields", func(t *testing.T) {
+		t.Run("different selector", func(t *testing.T) {
+			result, err := statefulset.MaybeUpdate(
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test"}}}},
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}}},
+			)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+		t.Run("different service name", func(t *testing.T) {
+			result, err := statefulset.MaybeUpdate(
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{ServiceName: "test"}},
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{ServiceName: "other"}},
+			)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+		t.Run("different pod management policy", func(t *testing.T) {
+			result, err := statefulset.MaybeUpdate(
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{PodManagementPolicy: appsv1.OrderedReadyPodManagement}},
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{PodManagementPolicy: appsv1.ParallelPodManagement}},
+			)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+		t.Run("defaulted pod management policy", func(t *testing.T) {
+			result, err := statefulset.MaybeUpdate(
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{PodManagementPolicy: appsv1.OrderedReadyPodManagement}},
+				&appsv1.StatefulSet{},
+			)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different number of volume claim templates", func(t *testing.T) {
+			result, err := statefulset.MaybeUpdate(
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{VolumeClaimTemplates: []corev1.PersistentVolumeClaim{testClaim("data", "1Gi")}}},
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{VolumeClaimTemplates: []corev1.PersistentVolumeClaim{testClaim("data", "1Gi"), testClaim("logs", "1Gi")}}},
+			)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+		t.Run("different volume claim template size", func(t *testing.T) {
+			result, err := statefulset.MaybeUpdate(
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{VolumeClaimTemplates: []corev1.PersistentVolumeClaim{testClaim("data", "1Gi")}}},
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{VolumeClaimTemplates: []corev1.PersistentVolumeClaim{testClaim("data", "2Gi")}}},
+			)
+			assert.True(t, operation.IsImmutableFieldError(err))
+			assert.False(t, result)
+		})
+		t.Run("defaulted volume claim template", func(t *testing.T) {
+			existingClaim := testClaim("data", "1Gi")
+			filesystem := corev1.PersistentVolumeFilesystem
+			existingClaim.Spec.VolumeMode = &filesystem
+			existingClaim.Status.Phase = corev1.ClaimPending
+
+			result, err := statefulset.MaybeUpdate(
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{VolumeClaimTemplates: []corev1.PersistentVolumeClaim{existingClaim}}},
+				&appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{VolumeClaimTemplates: []corev1.PersistentVolumeClaim{testClaim("data", "1Gi")}}},
+			)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare statefulsets", func(t *testing.T) {
+		t.Run("empty statefulsets", func(t *testing.T) {
+			result, err := statefulset.MaybeUpdate(&appsv1.StatefulSet{}, &appsv1.StatefulSet{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different replicas", func(t *testing.T) {
+			existingStatefulSet := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: int32Ptr(1)}}
+			newStatefulSet := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: int32Ptr(3)}}
+
+			result, err := statefulset.MaybeUpdate(existingStatefulSet, newStatefulSet)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingStatefulSet, newStatefulSet)
+		})
+		t.Run("unset replicas", func(t *testing.T) {
+			existingStatefulSet := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{Replicas: int32Ptr(1)}}
+			newStatefulSet := &appsv1.StatefulSet{}
+
+			result, err := statefulset.MaybeUpdate(existingStatefulSet, newStatefulSet)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different update strategy", func(t *testing.T) {
+			existingStatefulSet := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType},
+			}}
+			newStatefulSet := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{
+				UpdateStrategy: appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType},
+			}}
+
+			result, err := statefulset.MaybeUpdate(existingStatefulSet, newStatefulSet)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingStatefulSet, newStatefulSet)
+		})
+		t.Run("different pod template", func(t *testing.T) {
+			existingStatefulSet := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v1", ImagePullPolicy: corev1.PullIfNotPresent}},
+				}},
+			}}
+			newStatefulSet := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v2"}},
+				}},
+			}}
+
+			result, err := statefulset.MaybeUpdate(existingStatefulSet, newStatefulSet)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingStatefulSet, newStatefulSet)
+		})
+		t.Run("removed container and volume", func(t *testing.T) {
+			existingStatefulSet := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{Name: "test", Image: "test:v1", VolumeMounts: []corev1.VolumeMount{{Name: "data", MountPath: "/data"}}},
+						{Name: "sidecar", Image: "sidecar:v1"},
+					},
+					Volumes: []corev1.Volume{{Name: "data"}},
+				}},
+			}}
+			newStatefulSet := &appsv1.StatefulSet{Spec: appsv1.StatefulSetSpec{
+				Template: corev1.PodTemplateSpec{Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "test", Image: "test:v1"}},
+				}},
+			}}
+
+			result, err := statefulset.MaybeUpdate(existingStatefulSet, newStatefulSet)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingStatefulSet, newStatefulSet)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := statefulset.Create(statefulset.Conf{GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := statefulset.Create(statefulset.Conf{GenStatefulSetFunc: func(statefulset.Conf) (*appsv1.StatefulSet, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := statefulset.Create(statefulset.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create statefulset", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := statefulset.Create(statefulset.Conf{
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := statefulset.Update(statefulset.Conf{GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := statefulset.Update(statefulset.Conf{GenStatefulSetFunc: func(statefulset.Conf) (*appsv1.StatefulSet, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("immutable field changed", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := statefulset.Update(statefulset.Conf{
+			Name:            "test-existing-statefulset",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			GenSelectorFunc: testSelector,
+			ServiceName:     "other",
+		})
+		assert.True(t, operation.IsImmutableFieldError(err))
+	})
+	t.Run("custom maybeupdate function", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := statefulset.Update(statefulset.Conf{
+			Name:            "test-existing-statefulset",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			MaybeUpdateFunc: func(interfaces.Object, interfaces.Object) (bool, error) { return true, nil },
+		})
+		assert.NoError(t, err)
+	})
+	t.Run("update statefulset", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := statefulset.Update(statefulset.Conf{
+			Name:            "test-existing-statefulset",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			GenSelectorFunc: testSelector,
+			GenReplicasFunc: func(interfaces.Object) (*int32, error) { return int32Ptr(3), nil },
+			ServiceName:     "test",
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := statefulset.CreateOrUpdate(statefulset.Conf{GenSelectorFunc: func(interfaces.Object) (*metav1.LabelSelector, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := statefulset.CreateOrUpdate(statefulset.Conf{GenStatefulSetFunc: func(statefulset.Conf) (*appsv1.StatefulSet, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := statefulset.CreateOrUpdate(statefulset.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := statefulset.CreateOrUpdate(statefulset.Conf{
+			Name:            "test-existing-statefulset",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			GenSelectorFunc: testSelector,
+			ServiceName:     "test",
+			AfterUpdateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update statefulset", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := statefulset.CreateOrUpdate(statefulset.Conf{
+			Name:            "test-existing-statefulset",
+			Namespace:       "test",
+			Instance:        i,
+			Reconcile:       r,
+			GenSelectorFunc: testSelector,
+			ServiceName:     "test",
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := statefulset.Delete(statefulset.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to delete", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := statefulset.Delete(statefulset.Conf{
+			Instance:  i,
+			Reconcile: r,
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("delete statefulset", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := statefulset.Delete(statefulset.Conf{
+			Name:      "test-existing-statefulset",
+			Namespace: "test",
+			Instance:  i,
+			Reconcile: r,
+		})
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,104 @@
+package statefulset
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GenStatefulSetFunc defines a function which generates StatefulSet
+type GenStatefulSetFunc func(Conf) (*appsv1.StatefulSet, error)
+
+// GenPodTemplateSpecFunc defines a function which generates
+// PodTemplateSpec for the StatefulSet
+type GenPodTemplateSpecFunc func(interfaces.Object) (*corev1.PodTemplateSpec, error)
+
+// GenContainersFunc defines a function which generates slice of
+// Container for the Pod template of the StatefulSet
+type GenContainersFunc func(interfaces.Object) ([]corev1.Container, error)
+
+// GenReplicasFunc defines a function which generates number of
+// replicas for the StatefulSet
+type GenReplicasFunc func(interfaces.Object) (*int32, error)
+
+// GenSelectorFunc defines a function which generates label selector
+// for the StatefulSet
+type GenSelectorFunc func(interfaces.Object) (*metav1.LabelSelector, error)
+
+// GenVolumeClaimTemplatesFunc defines a function which generates
+// slice of PersistentVolumeClaim templates for the StatefulSet
+type GenVolumeClaimTemplatesFunc func(interfaces.Object) ([]corev1.PersistentVolumeClaim, error)
+
+// GenUpdateStrategyFunc defines a function which generates the
+// strategy used to update Pods of the StatefulSet
+type GenUpdateStrategyFunc func(interfaces.Object) (appsv1.StatefulSetUpdateStrategy, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on StatefulSet objects.
+type Conf struct {
+	// Instance is the Owner object which manages the StatefulSet
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// Name of the StatefulSet
+	Name string
+	// Namespace of the StatefulSet
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on StatefulSet before creating it in cluster
+	OwnerReference bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for StatefulSet update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the StatefulSet
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the StatefulSet
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the StatefulSet
+	operation.AfterDeleteFunc
+	// GenStatefulSetFunc defines a function to generate the
+	// StatefulSet object. The package comes with default statefulset
+	// generator function which is used by operation functions. By
+	// specifying this field, user can override the default function
+	// with a custom one.
+	GenStatefulSetFunc
+	// GenPodTemplateSpecFunc defines a function to generate the Pod
+	// template for the StatefulSet
+	GenPodTemplateSpecFunc
+	// GenContainersFunc defines a function to generate containers
+	// for the Pod template. If specified, the generated containers
+	// replace the ones in the generated Pod template.
+	GenContainersFunc
+	// GenReplicasFunc defines a function to generate number of
+	// replicas for the StatefulSet
+	GenReplicasFunc
+	// GenSelectorFunc defines a function to generate label selector
+	// for the StatefulSet
+	GenSelectorFunc
+	// GenVolumeClaimTemplatesFunc defines a function to generate
+	// volume claim templates for the StatefulSet
+	GenVolumeClaimTemplatesFunc
+	// GenUpdateStrategyFunc defines a function to generate update
+	// strategy for the StatefulSet
+	GenUpdateStrategyFunc
+	// ServiceName is the name of the governing Service of the
+	// StatefulSet
+	ServiceName string
+	// PodManagementPolicy defines the policy used to create Pods of
+	// the StatefulSet
+	PodManagementPolicy string
+}
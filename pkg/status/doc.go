@@ -0,0 +1,3 @@
+// Package status provides functions for updating the status
+// subresource of the owner object.
+package status
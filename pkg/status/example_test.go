@@ -0,0 +1,35 @@
+package status_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/status"
+
+	appsv1 "k8s.io/api/apps/v1"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleUpdate() {
+	err := status.Update(status.Conf{
+		// Instance is the pointer to owner object whose status is
+		// updated.
+		Instance: ownerObject,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// SetStatusFunc sets the status on the owner object. It is
+		// called again on the freshly fetched owner object in case
+		// of conflict.
+		SetStatusFunc: func(o interfaces.Object) error {
+			o.(*appsv1.Deployment).Status.Replicas = 3
+			return nil
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
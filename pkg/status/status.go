@@ -0,0 +1,159 @@
+package status
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+)
+
+// Update sets the status of the Instance using SetStatusFunc from the
+// `Conf` struct passed and writes it to the status subresource. The
+// write is skipped if the status is same as the one of the Instance
+// in the cluster, which is fetched first, so the status changed in
+// the cluster by someone else is set back too. Along with the status
+// set by SetStatusFunc, it sets `status.observedGeneration` to the
+// generation of the Instance, so the status tells which spec it was
+// computed for. If the update fails with conflict, the Instance is
+// fetched again and the status is set on it once more. The Instance
+// is updated in place with the result.
+func Update(c Conf) error {
+	return UpdateWithContext(context.Background(), c)
+}
+
+// UpdateWithContext is same as Update but uses the context passed for
+// the calls to API Server.
+func UpdateWithContext(ctx context.Context, c Conf) error {
+	backoff := retry.DefaultRetry
+	if c.Backoff != nil {
+		backoff = *c.Backoff
+	}
+
+	attempt := 0
+	err := retry.RetryOnConflict(backoff, func() error {
+		attempt++
+		existing, err := fetch(ctx, c)
+		if err != nil {
+			return err
+		}
+		// The Instance is replaced on conflict, so that the status is
+		// set on the latest version.
+		if attempt > 1 {
+			reflect.ValueOf(c.Instance).Elem().Set(reflect.ValueOf(existing.DeepCopyObject()).Elem())
+		}
+
+		return update(ctx, c, existing)
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to update status")
+	}
+
+	return nil
+}
+
+// update sets the status on the Instance and writes it, if different
+// from the status of the existing Instance fetched from the cluster.
+func update(ctx context.Context, c Conf, existing interfaces.Object) error {
+	original, err := status(existing)
+	if err != nil {
+		return err
+	}
+
+	if c.SetStatusFunc != nil {
+		if err = c.SetStatusFunc(c.Instance); err != nil {
+			return errors.Wrap(err, "failed to set status")
+		}
+	}
+
+	err = setObservedGeneration(c.Instance)
+	if err != nil {
+		return errors.Wrap(err, "failed to set observed generation")
+	}
+
+	modified, err := status(c.Instance)
+	if err != nil {
+		return err
+	}
+
+	if equality.Semantic.DeepEqual(original, modified) {
+		return nil
+	}
+
+	cctx, cancel := operation.CallContext(ctx, c.Timeout)
+	defer cancel()
+	return c.Reconcile.GetClient().Status().Update(cctx, c.Instance)
+}
+
+// fetch gets the Instance from the cluster into a new object.
+func fetch(ctx context.Context, c Conf) (interfaces.Object, error) {
+	fresh, ok := reflect.New(reflect.TypeOf(c.Instance).Elem()).Interface().(interfaces.Object)
+	if !ok {
+		return nil, errors.New("failed to create the instance")
+	}
+	if u, ok := fresh.(*unstructured.Unstructured); ok {
+		u.SetGroupVersionKind(c.Instance.GetObjectKind().GroupVersionKind())
+	}
+
+	cctx, cancel := operation.CallContext(ctx, c.Timeout)
+	defer cancel()
+	err := c.Reconcile.GetClient().Get(cctx, types.NamespacedName{Name: c.Instance.GetName(), Namespace: c.Instance.GetNamespace()}, fresh)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get the instance from cluster")
+	}
+
+	return fresh, nil
+}
+
+// status returns a copy of the status of the object in its
+// unstructured form.
+func status(o interfaces.Object) (interface{}, error) {
+	content, err := toUnstructured(o)
+	if err != nil {
+		return nil, err
+	}
+
+	return runtime.DeepCopyJSONValue(content["status"]), nil
+}
+
+// setObservedGeneration sets `status.observedGeneration` to the
+// generation of the object. The typed objects without the field in
+// their status are left as they are.
+func setObservedGeneration(o interfaces.Object) error {
+	if u, ok := o.(*unstructured.Unstructured); ok {
+		return unstructured.SetNestedField(u.Object, o.GetGeneration(), "status", "observedGeneration")
+	}
+
+	content, err := toUnstructured(o)
+	if err != nil {
+		return err
+	}
+
+	err = unstructured.SetNestedField(content, o.GetGeneration(), "status", "observedGeneration")
+	if err != nil {
+		return err
+	}
+
+	return runtime.DefaultUnstructuredConverter.FromUnstructured(content, o)
+}
+
+// toUnstructured returns the content of the object as map.
+func toUnstructured(o interfaces.Object) (map[string]interface{}, error) {
+	if u, ok := o.(*unstructured.Unstructured); ok {
+		return u.Object, nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(o)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to convert the instance")
+	}
+
+	return content, nil
+}
@@ -0,0 +1,182 @@
+package status_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/status"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// statusClient counts the status updates and fails the first of them
+// with conflict.
+type statusClient struct {
+	client.Client
+	conflicts int
+	updates   int
+}
+
+func (c *statusClient) Status() client.StatusWriter {
+	return &statusWriter{StatusWriter: c.Client.Status(), c: c}
+}
+
+type statusWriter struct {
+	client.StatusWriter
+	c *statusClient
+}
+
+func (w *statusWriter) Update(ctx context.Context, obj runtime.Object, opts ...client.UpdateOption) error {
+	w.c.updates++
+	if w.c.updates <= w.c.conflicts {
+		return kerrors.NewConflict(schema.GroupResource{Resource: "deployments"}, "test", errors.New("test error"))
+	}
+	return w.StatusWriter.Update(ctx, obj, opts...)
+}
+
+func mockSetup(ctrl *gomock.Controller, conflicts int) (i *appsv1.Deployment, r *mocks.MockReconcile, c *statusClient) {
+	i = &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "test", Generation: 2},
+		Status:     appsv1.DeploymentStatus{Replicas: 1, ObservedGeneration: 2},
+	}
+	r = mocks.NewMockReconcile(ctrl)
+
+	// TypeMeta is set on the seeded object so that it can also be
+	// fetched as unstructured.
+	seed := i.DeepCopy()
+	seed.TypeMeta = metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}
+	c = &statusClient{Client: fake.NewFakeClient(seed), conflicts: conflicts}
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(scheme.Scheme).AnyTimes()
+
+	return i, r, c
+}
+
+func getDeployment(t *testing.T, r interfaces.Reconcile) *appsv1.Deployment {
+	d := &appsv1.Deployment{}
+	err := r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test", Namespace: "test"}, d)
+	assert.NoError(t, err)
+	return d
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	setReplicas := func(replicas int32) status.SetStatusFunc {
+		return func(o interfaces.Object) error {
+			o.(*appsv1.Deployment).Status.Replicas = replicas
+			return nil
+		}
+	}
+
+	t.Run("update status", func(t *testing.T) {
+		i, r, c := mockSetup(controller, 0)
+
+		err := status.Update(status.Conf{Instance: i, Reconcile: r, SetStatusFunc: setReplicas(3)})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, c.updates)
+		assert.Equal(t, int32(3), getDeployment(t, r).Status.Replicas)
+	})
+	t.Run("update observed generation", func(t *testing.T) {
+		i, r, c := mockSetup(controller, 0)
+		i.SetGeneration(3)
+
+		err := status.Update(status.Conf{Instance: i, Reconcile: r, SetStatusFunc: setReplicas(1)})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, c.updates)
+		assert.Equal(t, int64(3), i.Status.ObservedGeneration)
+		assert.Equal(t, int64(3), getDeployment(t, r).Status.ObservedGeneration)
+	})
+	t.Run("skip unchanged status", func(t *testing.T) {
+		i, r, c := mockSetup(controller, 0)
+
+		err := status.Update(status.Conf{Instance: i, Reconcile: r, SetStatusFunc: setReplicas(1)})
+		assert.NoError(t, err)
+		assert.Equal(t, 0, c.updates)
+	})
+	t.Run("correct status changed in cluster", func(t *testing.T) {
+		i, r, c := mockSetup(controller, 0)
+		changed := getDeployment(t, r)
+		changed.Status.Replicas = 5
+		err := c.Client.Status().Update(context.TODO(), changed)
+		assert.NoError(t, err)
+
+		err = status.Update(status.Conf{Instance: i, Reconcile: r, SetStatusFunc: setReplicas(1)})
+		assert.NoError(t, err)
+		assert.NotZero(t, c.updates)
+		assert.Equal(t, int32(1), getDeployment(t, r).Status.Replicas)
+	})
+	t.Run("retry on conflict", func(t *testing.T) {
+		i, r, c := mockSetup(controller, 1)
+		calls := 0
+
+		err := status.Update(status.Conf{
+			Instance:  i,
+			Reconcile: r,
+			SetStatusFunc: func(o interfaces.Object) error {
+				calls++
+				o.(*appsv1.Deployment).Status.Replicas = 3
+				return nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		assert.Equal(t, 2, c.updates)
+		assert.Equal(t, int32(3), getDeployment(t, r).Status.Replicas)
+	})
+	t.Run("conflict after retries", func(t *testing.T) {
+		i, r, c := mockSetup(controller, 3)
+
+		err := status.Update(status.Conf{
+			Instance:      i,
+			Reconcile:     r,
+			SetStatusFunc: setReplicas(3),
+			Backoff:       &wait.Backoff{Steps: 2},
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 2, c.updates)
+	})
+	t.Run("set status fails", func(t *testing.T) {
+		i, r, c := mockSetup(controller, 0)
+
+		err := status.Update(status.Conf{
+			Instance:  i,
+			Reconcile: r,
+			SetStatusFunc: func(interfaces.Object) error {
+				return errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+		assert.Equal(t, 0, c.updates)
+	})
+	t.Run("update status of unstructured instance", func(t *testing.T) {
+		_, r, c := mockSetup(controller, 0)
+		i := &unstructured.Unstructured{}
+		i.SetGroupVersionKind(schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+		i.SetName("test")
+		i.SetNamespace("test")
+		i.SetGeneration(4)
+
+		err := status.Update(status.Conf{Instance: i, Reconcile: r})
+		assert.NoError(t, err)
+		assert.Equal(t, 1, c.updates)
+		assert.Equal(t, int64(4), getDeployment(t, r).Status.ObservedGeneration)
+	})
+}
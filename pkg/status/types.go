@@ -0,0 +1,33 @@
+package status
+
+import (
+	"time"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// SetStatusFunc defines a function which sets the status on the
+// Instance passed. It is called again on the freshly fetched Instance
+// if the update fails with conflict, so it should only depend on the
+// Instance and the state observed in the reconcile.
+type SetStatusFunc func(interfaces.Object) error
+
+// Conf is used to pass parameters to functions in this package to
+// update the status of the owner object.
+type Conf struct {
+	// Instance is the Owner object whose status is updated
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// SetStatusFunc defines a function to set the status on the
+	// Instance
+	SetStatusFunc
+	// Backoff is used to retry the update when it fails with
+	// conflict. retry.DefaultRetry from client-go is used if nil
+	Backoff *wait.Backoff
+	// Timeout limits the duration of every call made to the API
+	// Server while updating the status
+	Timeout time.Duration
+}
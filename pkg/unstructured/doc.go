@@ -0,0 +1,12 @@
+// Package unstructured provides functions for manipulating objects of
+// arbitrary kind in Kubernetes cluster. It is useful for managing
+// custom resources from third-party operators without importing their
+// Go types. The object is described by its GroupVersionKind and a
+// body, either as a map or as YAML. The body can contain any fields
+// of the object and the `Conf` struct fills in the type and the
+// ObjectMeta fields.
+//
+// MaybeUpdate only compares the fields which are set in the generated
+// object, so fields defaulted by API Server or set by other
+// controllers do not cause updates.
+package unstructured
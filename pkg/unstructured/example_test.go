@@ -0,0 +1,52 @@
+package unstructured_test
+
+import (
+	"log"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/unstructured"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+var ownerObject interfaces.Object
+var ownerReconcile interfaces.Reconcile
+
+func ExampleCreateOrUpdate() {
+	result, err := unstructured.CreateOrUpdate(unstructured.Conf{
+		// Instance is the pointer to owner object under which the
+		// object is being created.
+		Instance: ownerObject,
+		// OwnerReference can be used to tell if owner reference is
+		// required to set on the object.
+		OwnerReference: true,
+		// Reconcile is the reconcile struct of the owner object which
+		// implements the interfaces.Reconcile struct. For more
+		// details check Reconcile interface documentation.
+		Reconcile: ownerReconcile,
+		// GroupVersionKind is the type of the object.
+		GroupVersionKind: schema.GroupVersionKind{
+			Group:   "cert-manager.io",
+			Version: "v1",
+			Kind:    "Certificate",
+		},
+		// Name is the name of generated object.
+		Name: "certificate-test",
+		// GenYAMLFunc is the function that generates the body of the
+		// object. Only the fields set here are compared on update.
+		GenYAMLFunc: func(interfaces.Object) ([]byte, error) {
+			return []byte(`
+spec:
+  secretName: certificate-test-tls
+  dnsNames:
+  - example.com
+  issuerRef:
+    name: letsencrypt
+    kind: ClusterIssuer
+`), nil
+		},
+	})
+	if err != nil {
+		log.Fatal(result, err)
+	}
+}
@@ -0,0 +1,73 @@
+package unstructured
+
+import (
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// GenUnstructuredFunc defines a function which generates Unstructured
+// object
+type GenUnstructuredFunc func(Conf) (*kunstructured.Unstructured, error)
+
+// GenObjectFunc defines a function which generates the body of the
+// object as a map
+type GenObjectFunc func(interfaces.Object) (map[string]interface{}, error)
+
+// GenYAMLFunc defines a function which generates the body of the
+// object as YAML
+type GenYAMLFunc func(interfaces.Object) ([]byte, error)
+
+// Conf is used to pass parameters to functions in this package to
+// perform operations on Unstructured objects.
+type Conf struct {
+	// Instance is the Owner object which manages the object
+	Instance interfaces.Object
+	// Reconcile is the pointer to reconcile struct of owner object
+	interfaces.Reconcile
+	// GroupVersionKind is the type of the object. It overrides the
+	// apiVersion and kind in the generated body.
+	schema.GroupVersionKind
+	// Name of the object. If empty, name from the body is used.
+	Name string
+	// Namespace of the object. If empty, namespace from the body is
+	// used.
+	Namespace string
+	// GenLabelsFunc is used to generate labels for ObjectMeta
+	meta.GenLabelsFunc
+	// GenAnnotationsFunc is used to generate annotations for
+	// ObjectMeta
+	meta.GenAnnotationsFunc
+	// GenFinalizers is used to generate finalizers for ObjectMeta
+	meta.GenFinalizersFunc
+	// AppendLabels is used to determine if labels from Owner object
+	// are to be inherited
+	AppendLabels bool
+	// OwnerReference is used to determine if owner reference needs to
+	// be set on the object before creating it in cluster
+	OwnerReference bool
+	// MaybeUpdateFunc defines an update function with custom logic
+	// for the object update
+	operation.MaybeUpdateFunc
+	// AfterCreateFunc hook is called after creating the object
+	operation.AfterCreateFunc
+	// AfterUpdateFunc hook is called after updating the object
+	operation.AfterUpdateFunc
+	// AfterDeleteFunc hook is called after deleting the object
+	operation.AfterDeleteFunc
+	// GenUnstructuredFunc defines a function to generate the
+	// Unstructured object. The package comes with default generator
+	// function which is used by operation functions. By specifying
+	// this field, user can override the default function with a
+	// custom one.
+	GenUnstructuredFunc
+	// GenObjectFunc defines a function to generate the body of the
+	// object as a map. It is mutually exclusive with GenYAMLFunc.
+	GenObjectFunc
+	// GenYAMLFunc defines a function to generate the body of the
+	// object as YAML. It is mutually exclusive with GenObjectFunc.
+	GenYAMLFunc
+}
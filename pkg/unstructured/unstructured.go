@@ -0,0 +1,317 @@
+package unstructured
+
+import (
+	"encoding/json"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/meta"
+	"github.com/ankitrgadiya/operatorlib/pkg/operation"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utiljson "k8s.io/apimachinery/pkg/util/json"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/yaml"
+)
+
+// GenerateUnstructured generates Unstructured object as per the
+// `Conf` struct passed. The body is generated using either
+// GenObjectFunc or GenYAMLFunc and is normalized the way API Server
+// returns objects, so numbers are int64 or float64. GroupVersionKind,
+// Name, Namespace and Finalizers from `Conf` override the ones in the
+// body while generated labels and annotations are merged into it.
+func GenerateUnstructured(c Conf) (u *kunstructured.Unstructured, err error) {
+	var om *metav1.ObjectMeta
+	var body []byte
+
+	if c.GenObjectFunc != nil && c.GenYAMLFunc != nil {
+		return nil, errors.New("only one of GenObjectFunc and GenYAMLFunc can be set")
+	}
+
+	om, err = meta.GenerateObjectMeta(meta.Conf{
+		Instance:           c.Instance,
+		Name:               c.Name,
+		Namespace:          c.Namespace,
+		GenLabelsFunc:      c.GenLabelsFunc,
+		GenAnnotationsFunc: c.GenAnnotationsFunc,
+		GenFinalizersFunc:  c.GenFinalizersFunc,
+		AppendLabels:       c.AppendLabels,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate objectmeta")
+	}
+
+	if c.GenObjectFunc != nil {
+		var o map[string]interface{}
+		o, err = c.GenObjectFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate object")
+		}
+		body, err = json.Marshal(o)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to encode object")
+		}
+	}
+
+	if c.GenYAMLFunc != nil {
+		var y []byte
+		y, err = c.GenYAMLFunc(c.Instance)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate yaml")
+		}
+		body, err = yaml.YAMLToJSON(y)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to convert yaml to json")
+		}
+	}
+
+	u = &kunstructured.Unstructured{}
+	if body != nil {
+		// JSON package of API machinery decodes whole numbers as
+		// int64 like the objects read from the cluster.
+		err = utiljson.Unmarshal(body, &u.Object)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to decode object")
+		}
+	}
+	if u.Object == nil {
+		u.Object = map[string]interface{}{}
+	}
+
+	if !c.GroupVersionKind.Empty() {
+		u.SetGroupVersionKind(c.GroupVersionKind)
+	}
+	if om.Name != "" {
+		u.SetName(om.Name)
+	}
+	if om.Namespace != "" {
+		u.SetNamespace(om.Namespace)
+	}
+	if len(om.Labels) > 0 {
+		u.SetLabels(merge(u.GetLabels(), om.Labels))
+	}
+	if len(om.Annotations) > 0 {
+		u.SetAnnotations(merge(u.GetAnnotations(), om.Annotations))
+	}
+	if len(om.Finalizers) > 0 {
+		u.SetFinalizers(om.Finalizers)
+	}
+
+	return u, nil
+}
+
+// MaybeUpdate implements MaybeUpdateFunc for Unstructured object. It
+// compares the two objects being passed and update the first one if
+// required. Every top-level field of the new object, except the type,
+// metadata and status, is compared. Only the keys set in the new
+// object are compared for maps, while lists must have the same
+// length. A field which is different is replaced as a whole.
+func MaybeUpdate(original interfaces.Object, new interfaces.Object) (bool, error) {
+	ou, ok := original.(*kunstructured.Unstructured)
+	if !ok {
+		return false, errors.New("failed to assert the original object")
+	}
+
+	nu, ok := new.(*kunstructured.Unstructured)
+	if !ok {
+		return false, errors.New("failed to assert the new object")
+	}
+
+	update := false
+
+	for k, v := range nu.Object {
+		switch k {
+		case "apiVersion", "kind", "metadata", "status":
+			continue
+		}
+
+		if !specified(v, ou.Object[k]) {
+			if ou.Object == nil {
+				ou.Object = map[string]interface{}{}
+			}
+			ou.Object[k] = v
+			update = true
+		}
+	}
+
+	return update, nil
+}
+
+// Create generates the object as per the `Conf` struct passed and
+// creates it in the cluster
+func Create(c Conf) (reconcile.Result, error) {
+	var u *kunstructured.Unstructured
+	var err error
+	if c.GenUnstructuredFunc != nil {
+		u, err = c.GenUnstructuredFunc(c)
+	} else {
+		u, err = GenerateUnstructured(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate object")
+	}
+
+	result, err := operation.Create(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          u,
+		OwnerReference:  c.OwnerReference,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create object")
+	}
+
+	return result, nil
+}
+
+// Update generates the object as per the `Conf` struct passed and
+// compares it with the in-cluster version. If required, it updates
+// the in-cluster object with the changes. For comparing the objects,
+// it uses `MaybeUpdate` function by default but can also use
+// `MaybeUpdateFunc` from `Conf` if passed.
+func Update(c Conf) (reconcile.Result, error) {
+	var u *kunstructured.Unstructured
+	var err error
+	if c.GenUnstructuredFunc != nil {
+		u, err = c.GenUnstructuredFunc(c)
+	} else {
+		u, err = GenerateUnstructured(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate object")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.Update(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          u,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to update object")
+	}
+
+	return result, nil
+}
+
+// CreateOrUpdate is a combination of `Create` and `Update` functions.
+// It creates the object if it is not already in the cluster and
+// updates the object if one exists.
+func CreateOrUpdate(c Conf) (reconcile.Result, error) {
+	var u *kunstructured.Unstructured
+	var err error
+	if c.GenUnstructuredFunc != nil {
+		u, err = c.GenUnstructuredFunc(c)
+	} else {
+		u, err = GenerateUnstructured(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate object")
+	}
+
+	var maybeUpdateFunc operation.MaybeUpdateFunc
+	if c.MaybeUpdateFunc != nil {
+		maybeUpdateFunc = c.MaybeUpdateFunc
+	} else {
+		maybeUpdateFunc = MaybeUpdate
+	}
+
+	result, err := operation.CreateOrUpdate(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          u,
+		OwnerReference:  c.OwnerReference,
+		MaybeUpdateFunc: maybeUpdateFunc,
+		AfterUpdateFunc: c.AfterUpdateFunc,
+		AfterCreateFunc: c.AfterCreateFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to create or update object")
+	}
+
+	return result, nil
+}
+
+// Delete generates the object as per the `Conf` struct passed and
+// deletes it from the cluster. Unlike the packages for typed objects,
+// the whole object is generated as the name can be set in the body.
+func Delete(c Conf) (reconcile.Result, error) {
+	var u *kunstructured.Unstructured
+	var err error
+	if c.GenUnstructuredFunc != nil {
+		u, err = c.GenUnstructuredFunc(c)
+	} else {
+		u, err = GenerateUnstructured(c)
+	}
+	if err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "failed to generate object")
+	}
+
+	result, err := operation.Delete(operation.Conf{
+		Instance:        c.Instance,
+		Reconcile:       c.Reconcile,
+		Object:          u,
+		AfterDeleteFunc: c.AfterDeleteFunc,
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "failed to delete object")
+	}
+
+	return result, nil
+}
+
+// merge returns the union of two string maps. Values from the second
+// map take precedence.
+func merge(a, b map[string]string) map[string]string {
+	m := make(map[string]string, len(a)+len(b))
+	for k, v := range a {
+		m[k] = v
+	}
+	for k, v := range b {
+		m[k] = v
+	}
+	return m
+}
+
+// specified reports if all the fields set in n have the same value in
+// o. Maps are compared only on the keys set in n, while lists must
+// have the same length so that removed items are detected.
+func specified(n, o interface{}) bool {
+	switch nv := n.(type) {
+	case map[string]interface{}:
+		ov, ok := o.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range nv {
+			if !specified(v, ov[k]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		ov, ok := o.([]interface{})
+		if !ok || len(nv) != len(ov) {
+			return false
+		}
+		for i := range nv {
+			if !specified(nv[i], ov[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return equality.Semantic.DeepEqual(n, o)
+	}
+}
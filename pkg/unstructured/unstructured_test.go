@@ -0,0 +1,403 @@
+package unstructured_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces"
+	"github.com/ankitrgadiya/operatorlib/pkg/interfaces/mocks"
+	"github.com/ankitrgadiya/operatorlib/pkg/unstructured"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	kunstructured "k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+var gvk = schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "ServiceMonitor"}
+
+func mockSetup(ctrl *gomock.Controller) (i *mocks.MockObject, r *mocks.MockReconcile) {
+	i = mocks.NewMockObject(ctrl)
+	i.EXPECT().GetName().Return("test").AnyTimes()
+	i.EXPECT().GetUID().Return(types.UID("199bd7a8-b72a-4411-b55e-91096769e58f")).AnyTimes()
+
+	u := &kunstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "monitoring.coreos.com/v1",
+		"kind":       "ServiceMonitor",
+		"metadata": map[string]interface{}{
+			"name":      "test-existing-servicemonitor",
+			"namespace": "test",
+		},
+		"spec": map[string]interface{}{
+			"endpoints": []interface{}{map[string]interface{}{"port": "metrics"}},
+		},
+	}}
+	r = mocks.NewMockReconcile(ctrl)
+
+	c := fake.NewFakeClient([]runtime.Object{u}...)
+	s := scheme.Scheme
+	s.AddKnownTypes(schema.GroupVersion{Group: "test", Version: "v1"}, i)
+
+	r.EXPECT().GetClient().Return(c).AnyTimes()
+	r.EXPECT().GetScheme().Return(s).AnyTimes()
+
+	return i, r
+}
+
+func TestGenerateUnstructured(t *testing.T) {
+	t.Run("generate empty object", func(t *testing.T) {
+		result, err := unstructured.GenerateUnstructured(unstructured.Conf{})
+		assert.NoError(t, err)
+
+		assert.Equal(t, &kunstructured.Unstructured{Object: map[string]interface{}{}}, result)
+	})
+	t.Run("failed to generate objectmeta", func(t *testing.T) {
+		result, err := unstructured.GenerateUnstructured(unstructured.Conf{
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate object", func(t *testing.T) {
+		result, err := unstructured.GenerateUnstructured(unstructured.Conf{
+			GenObjectFunc: func(interfaces.Object) (map[string]interface{}, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("failed to generate yaml", func(t *testing.T) {
+		result, err := unstructured.GenerateUnstructured(unstructured.Conf{
+			GenYAMLFunc: func(interfaces.Object) ([]byte, error) { return nil, errors.New("test error") },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("invalid yaml", func(t *testing.T) {
+		result, err := unstructured.GenerateUnstructured(unstructured.Conf{
+			GenYAMLFunc: func(interfaces.Object) ([]byte, error) { return []byte("spec: [a"), nil },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("both object and yaml", func(t *testing.T) {
+		result, err := unstructured.GenerateUnstructured(unstructured.Conf{
+			GenObjectFunc: func(interfaces.Object) (map[string]interface{}, error) { return nil, nil },
+			GenYAMLFunc:   func(interfaces.Object) ([]byte, error) { return nil, nil },
+		})
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("generate from object", func(t *testing.T) {
+		expected := &kunstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind":       "ServiceMonitor",
+			"metadata": map[string]interface{}{
+				"name":      "test-servicemonitor",
+				"namespace": "test",
+				"labels":    map[string]interface{}{"app": "test", "release": "test"},
+			},
+			"spec": map[string]interface{}{
+				"endpoints": []interface{}{map[string]interface{}{"port": "metrics", "interval": "30s"}},
+				"replicas":  int64(2),
+			},
+		}}
+
+		result, err := unstructured.GenerateUnstructured(unstructured.Conf{
+			GroupVersionKind: gvk,
+			Name:             "test-servicemonitor",
+			Namespace:        "test",
+			GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+				return map[string]string{"app": "test"}, nil
+			},
+			GenObjectFunc: func(interfaces.Object) (map[string]interface{}, error) {
+				return map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"labels": map[string]interface{}{"release": "test"},
+					},
+					"spec": map[string]interface{}{
+						"endpoints": []map[string]string{{"port": "metrics", "interval": "30s"}},
+						"replicas":  2,
+					},
+				}, nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+	t.Run("generate from yaml", func(t *testing.T) {
+		expected := &kunstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "monitoring.coreos.com/v1",
+			"kind":       "ServiceMonitor",
+			"metadata": map[string]interface{}{
+				"name":      "test-servicemonitor",
+				"namespace": "test",
+			},
+			"spec": map[string]interface{}{
+				"endpoints": []interface{}{map[string]interface{}{"port": "metrics"}},
+				"replicas":  int64(2),
+			},
+		}}
+
+		result, err := unstructured.GenerateUnstructured(unstructured.Conf{
+			GroupVersionKind: gvk,
+			GenYAMLFunc: func(interfaces.Object) ([]byte, error) {
+				return []byte(`
+apiVersion: v1
+kind: Other
+metadata:
+  name: test-servicemonitor
+  namespace: test
+spec:
+  endpoints:
+  - port: metrics
+  replicas: 2
+`), nil
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, expected, result)
+	})
+}
+
+func TestMaybeUpdate(t *testing.T) {
+	t.Run("bad parameters", func(t *testing.T) {
+		t.Run("bad original object", func(t *testing.T) {
+			result, err := unstructured.MaybeUpdate(&mocks.MockObject{}, &kunstructured.Unstructured{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad new object", func(t *testing.T) {
+			result, err := unstructured.MaybeUpdate(&kunstructured.Unstructured{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+		t.Run("bad objects", func(t *testing.T) {
+			result, err := unstructured.MaybeUpdate(&mocks.MockObject{}, &mocks.MockObject{})
+			assert.Error(t, err)
+			assert.False(t, result)
+		})
+	})
+	t.Run("compare objects", func(t *testing.T) {
+		t.Run("empty objects", func(t *testing.T) {
+			result, err := unstructured.MaybeUpdate(&kunstructured.Unstructured{}, &kunstructured.Unstructured{})
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("unspecified fields are ignored", func(t *testing.T) {
+			existingObject := &kunstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test", "resourceVersion": "1"},
+				"spec": map[string]interface{}{
+					"endpoints": []interface{}{map[string]interface{}{"port": "metrics", "scheme": "http"}},
+					"replicas":  int64(2),
+				},
+				"status": map[string]interface{}{"ready": true},
+			}}
+			newObject := &kunstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": "test"},
+				"spec": map[string]interface{}{
+					"endpoints": []interface{}{map[string]interface{}{"port": "metrics"}},
+				},
+				"status": map[string]interface{}{"ready": false},
+			}}
+
+			result, err := unstructured.MaybeUpdate(existingObject, newObject)
+			assert.NoError(t, err)
+			assert.False(t, result)
+		})
+		t.Run("different field", func(t *testing.T) {
+			existingObject := &kunstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": int64(2)},
+				"data": map[string]interface{}{"key": "value"},
+			}}
+			newObject := &kunstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": int64(3)},
+			}}
+
+			result, err := unstructured.MaybeUpdate(existingObject, newObject)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, map[string]interface{}{
+				"spec": map[string]interface{}{"replicas": int64(3)},
+				"data": map[string]interface{}{"key": "value"},
+			}, existingObject.Object)
+		})
+		t.Run("item removed from list", func(t *testing.T) {
+			existingObject := &kunstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{"ports": []interface{}{"http", "metrics"}},
+			}}
+			newObject := &kunstructured.Unstructured{Object: map[string]interface{}{
+				"spec": map[string]interface{}{"ports": []interface{}{"http"}},
+			}}
+
+			result, err := unstructured.MaybeUpdate(existingObject, newObject)
+			assert.NoError(t, err)
+			assert.True(t, result)
+			assert.Equal(t, existingObject, newObject)
+		})
+	})
+}
+
+func TestCreate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := unstructured.Create(unstructured.Conf{GenObjectFunc: func(interfaces.Object) (map[string]interface{}, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to generate using custom generator function", func(t *testing.T) {
+		_, err := unstructured.Create(unstructured.Conf{GenUnstructuredFunc: func(unstructured.Conf) (*kunstructured.Unstructured, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := unstructured.Create(unstructured.Conf{
+			Instance:         i,
+			Reconcile:        r,
+			GroupVersionKind: gvk,
+			Name:             "test-existing-servicemonitor",
+			Namespace:        "test",
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create object", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := unstructured.Create(unstructured.Conf{
+			Instance:         i,
+			Reconcile:        r,
+			GroupVersionKind: gvk,
+			Name:             "test-servicemonitor",
+			Namespace:        "test",
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := unstructured.Update(unstructured.Conf{GenUnstructuredFunc: func(unstructured.Conf) (*kunstructured.Unstructured, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to update", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := unstructured.Update(unstructured.Conf{
+			Instance:         i,
+			Reconcile:        r,
+			GroupVersionKind: gvk,
+			Name:             "test-servicemonitor",
+			Namespace:        "test",
+		})
+		assert.Error(t, err)
+	})
+	t.Run("update object", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := unstructured.Update(unstructured.Conf{
+			Instance:         i,
+			Reconcile:        r,
+			GroupVersionKind: gvk,
+			Name:             "test-existing-servicemonitor",
+			Namespace:        "test",
+			GenYAMLFunc: func(interfaces.Object) ([]byte, error) {
+				return []byte("spec:\n  endpoints:\n  - port: web\n"), nil
+			},
+		})
+		assert.NoError(t, err)
+
+		u := &kunstructured.Unstructured{}
+		u.SetGroupVersionKind(gvk)
+		err = r.GetClient().Get(context.TODO(), types.NamespacedName{Name: "test-existing-servicemonitor", Namespace: "test"}, u)
+		assert.NoError(t, err)
+
+		endpoints, _, _ := kunstructured.NestedSlice(u.Object, "spec", "endpoints")
+		assert.Equal(t, []interface{}{map[string]interface{}{"port": "web"}}, endpoints)
+	})
+}
+
+func TestCreateOrUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := unstructured.CreateOrUpdate(unstructured.Conf{GenUnstructuredFunc: func(unstructured.Conf) (*kunstructured.Unstructured, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to create", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := unstructured.CreateOrUpdate(unstructured.Conf{
+			Instance:         i,
+			Reconcile:        r,
+			GroupVersionKind: gvk,
+			Name:             "test-servicemonitor",
+			Namespace:        "test",
+			AfterCreateFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("create or update object", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := unstructured.CreateOrUpdate(unstructured.Conf{
+			Instance:         i,
+			Reconcile:        r,
+			GroupVersionKind: gvk,
+			Name:             "test-existing-servicemonitor",
+			Namespace:        "test",
+		})
+		assert.NoError(t, err)
+	})
+}
+
+func TestDelete(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	t.Run("failed to generate", func(t *testing.T) {
+		_, err := unstructured.Delete(unstructured.Conf{GenLabelsFunc: func(interfaces.Object) (map[string]string, error) {
+			return nil, errors.New("test error")
+		}})
+		assert.Error(t, err)
+	})
+	t.Run("failed to delete", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := unstructured.Delete(unstructured.Conf{
+			Instance:         i,
+			Reconcile:        r,
+			GroupVersionKind: gvk,
+			Name:             "test-existing-servicemonitor",
+			Namespace:        "test",
+			AfterDeleteFunc: func(interfaces.Object, interfaces.Reconcile) (reconcile.Result, error) {
+				return reconcile.Result{}, errors.New("test error")
+			},
+		})
+		assert.Error(t, err)
+	})
+	t.Run("delete object", func(t *testing.T) {
+		i, r := mockSetup(controller)
+		_, err := unstructured.Delete(unstructured.Conf{
+			Instance:         i,
+			Reconcile:        r,
+			GroupVersionKind: gvk,
+			Name:             "test-existing-servicemonitor",
+			Namespace:        "test",
+		})
+		assert.NoError(t, err)
+	})
+}